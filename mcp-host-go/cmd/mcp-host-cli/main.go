@@ -0,0 +1,81 @@
+// Command mcp-host-cli provides small operational helpers for running an
+// mcp-host instance: issuing bearer tokens for the SSE MCP endpoint's
+// pkg/auth verifier, and an interactive repl session for driving tools and
+// resources by hand.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/auth"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "token":
+		runToken(os.Args[2:])
+	case "repl":
+		runRepl(os.Args[2:])
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: mcp-host-cli token issue --rights <json> [--ttl 24h] [--hmac-secret-env NAME]")
+	fmt.Fprintln(os.Stderr, "       mcp-host-cli repl")
+}
+
+func runToken(args []string) {
+	if len(args) < 1 || args[0] != "issue" {
+		usage()
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("token issue", flag.ExitOnError)
+	rightsJSON := fs.String("rights", "", `JSON object mapping MCP method to allowed operation patterns, e.g. {"tools/call":["navigate_to","get_dom_*"]}`)
+	ttl := fs.Duration("ttl", 24*time.Hour, "token lifetime")
+	hmacSecretEnv := fs.String("hmac-secret-env", "MCP_HOST_JWT_SECRET", "environment variable holding the HMAC signing secret")
+	fs.Parse(args[1:])
+
+	if *rightsJSON == "" {
+		fmt.Fprintln(os.Stderr, "error: --rights is required")
+		os.Exit(1)
+	}
+
+	var rights map[string][]string
+	if err := json.Unmarshal([]byte(*rightsJSON), &rights); err != nil {
+		fmt.Fprintf(os.Stderr, "error: invalid --rights JSON: %v\n", err)
+		os.Exit(1)
+	}
+
+	secret := os.Getenv(*hmacSecretEnv)
+	if secret == "" {
+		fmt.Fprintf(os.Stderr, "error: signing secret not set in %s\n", *hmacSecretEnv)
+		os.Exit(1)
+	}
+
+	issuer, err := auth.NewIssuer(auth.IssuerConfig{HMACSecret: []byte(secret)})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	token, err := issuer.Issue(rights, *ttl)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to issue token: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(token)
+}
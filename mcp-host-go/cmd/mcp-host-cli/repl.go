@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/browser"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/repl"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/resources"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/tools"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// driverRouter implements repl.Router directly over a fixed set of tools and
+// resources, without going through mcp.Server. There's no concrete
+// types.Messaging transport this binary can construct standalone (it's only
+// ever implemented by a browser extension's Native Messaging channel), so
+// this router is limited to the tools and resources that work against
+// types.BrowserDriver alone - the same chromedp fallback
+// pkg/browser.ChromedpDriver provides for CI/server/container use.
+type driverRouter struct {
+	tools     map[string]types.Tool
+	resources map[string]types.Resource
+}
+
+func (r *driverRouter) ListTools() ([]repl.ToolInfo, error) {
+	infos := make([]repl.ToolInfo, 0, len(r.tools))
+	for _, tool := range r.tools {
+		infos = append(infos, repl.ToolInfo{
+			Name:        tool.GetName(),
+			Description: tool.GetDescription(),
+			InputSchema: tool.GetInputSchema(),
+		})
+	}
+	return infos, nil
+}
+
+func (r *driverRouter) ExecuteTool(name string, args map[string]interface{}) (repl.ToolResult, error) {
+	tool, exists := r.tools[name]
+	if !exists {
+		return repl.ToolResult{}, fmt.Errorf("tool not found: %s", name)
+	}
+
+	result, err := tool.Execute(args)
+	if err != nil {
+		return repl.ToolResult{}, err
+	}
+
+	items := make([]repl.ToolResultItem, 0, len(result.Content))
+	for _, item := range result.Content {
+		items = append(items, repl.ToolResultItem{Type: item.Type, Text: item.Text})
+	}
+	return repl.ToolResult{Content: items}, nil
+}
+
+func (r *driverRouter) ReadResource(uri string) (interface{}, error) {
+	resource, exists := r.resources[uri]
+	if !exists {
+		return nil, fmt.Errorf("resource not found: %s", uri)
+	}
+	return resource.Read()
+}
+
+// runRepl launches a headless Chromium instance via browser.ChromedpDriver
+// and drives an interactive REPL session against the tools and resources
+// that can run on it standalone (navigate_to, get_dom_extra_elements,
+// browser://dom/query). set_value, fill_form and any other tool that only a
+// browser extension can fulfill are not available in this mode.
+func runRepl(args []string) {
+	log, err := logger.NewLogger("mcp-host-cli")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to create logger: %v\n", err)
+		os.Exit(1)
+	}
+
+	driver, err := browser.NewChromedpDriver(browser.ChromedpDriverConfig{Logger: log})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to start chromedp driver: %v\n", err)
+		os.Exit(1)
+	}
+	defer driver.Close()
+
+	navigateTo, err := tools.NewNavigateToTool(tools.NavigateToConfig{Logger: log, Driver: driver})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	extraElements, err := tools.NewGetDomExtraElementsTool(tools.GetDomExtraElementsConfig{Logger: log, Driver: driver})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	domQuery, err := resources.NewDomQueryResource(resources.DomQueryConfig{Logger: log, Driver: driver})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	router := &driverRouter{
+		tools: map[string]types.Tool{
+			navigateTo.GetName():    navigateTo,
+			extraElements.GetName(): extraElements,
+		},
+		resources: map[string]types.Resource{
+			domQuery.GetURI(): domQuery,
+		},
+	}
+
+	session, err := repl.New(repl.Config{Router: router, Out: os.Stdout})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(session.Run(os.Stdin))
+}
@@ -0,0 +1,63 @@
+// Command hello-plugin is the minimal reference implementation of
+// plugin.Implementation: a single tool with no resources and no custom RPC
+// methods, meant as a starting point for a real out-of-process plugin.
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/plugin"
+)
+
+type helloPlugin struct{}
+
+func (helloPlugin) ListTools(ctx context.Context) ([]plugin.Tool, error) {
+	return []plugin.Tool{
+		{
+			Name:        "hello",
+			Description: "Returns a friendly greeting for the given name",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name to greet",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
+	}, nil
+}
+
+func (helloPlugin) CallTool(ctx context.Context, name string, args map[string]interface{}) ([]plugin.ToolResultItem, error) {
+	if name != "hello" {
+		return nil, fmt.Errorf("unknown tool: %s", name)
+	}
+
+	who, _ := args["name"].(string)
+	if who == "" {
+		who = "world"
+	}
+
+	return []plugin.ToolResultItem{
+		{Type: "text", Text: fmt.Sprintf("Hello, %s!", who)},
+	}, nil
+}
+
+func (helloPlugin) ListResources(ctx context.Context) ([]plugin.Resource, error) {
+	return nil, nil
+}
+
+func (helloPlugin) ReadResource(ctx context.Context, uri string, arguments map[string]interface{}) ([]plugin.ResourceItem, error) {
+	return nil, fmt.Errorf("hello-plugin exposes no resources")
+}
+
+func (helloPlugin) HandleRpc(ctx context.Context, method string, params map[string]interface{}) (interface{}, error) {
+	return nil, fmt.Errorf("hello-plugin does not handle RPC method %q", method)
+}
+
+func main() {
+	plugin.Serve(helloPlugin{})
+}
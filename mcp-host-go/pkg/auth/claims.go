@@ -0,0 +1,40 @@
+// Package auth issues and verifies the bearer tokens that gate the SSE MCP
+// endpoint, so a host reachable from other machines can scope an external
+// agent down to only the tools and resources it needs rather than handing it
+// full browser control.
+package auth
+
+import (
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims are the custom JWT claims this package issues and verifies. Rights
+// maps an MCP method (e.g. "tools/call", "resources/read") to the operation
+// name patterns a token may invoke under that method, e.g.
+//
+//	{"tools/call": ["navigate_to", "get_dom_*"], "resources/read": ["browser://current/*"]}
+type Claims struct {
+	Rights map[string][]string `json:"rights"`
+	jwt.RegisteredClaims
+}
+
+// Allows reports whether these claims grant method over operation, e.g.
+// Allows("tools/call", "navigate_to"). A pattern ending in "*" matches any
+// operation sharing that prefix; any other pattern must match exactly.
+func (c Claims) Allows(method, operation string) bool {
+	for _, pattern := range c.Rights[method] {
+		if matchesPattern(pattern, operation) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesPattern(pattern, operation string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(operation, prefix)
+	}
+	return pattern == operation
+}
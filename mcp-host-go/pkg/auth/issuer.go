@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// IssuerConfig configures an Issuer. Exactly one of HMACSecret or
+// RSAPrivateKey must be set, selecting which algorithm issued tokens are
+// signed with: HS256 for HMACSecret, RS256 for RSAPrivateKey.
+type IssuerConfig struct {
+	HMACSecret    []byte
+	RSAPrivateKey *rsa.PrivateKey
+}
+
+// Issuer signs bearer tokens for the SSE MCP endpoint.
+type Issuer struct {
+	hmacSecret []byte
+	rsaKey     *rsa.PrivateKey
+}
+
+// NewIssuer creates an Issuer.
+func NewIssuer(config IssuerConfig) (*Issuer, error) {
+	if len(config.HMACSecret) == 0 && config.RSAPrivateKey == nil {
+		return nil, fmt.Errorf("either HMACSecret or RSAPrivateKey is required")
+	}
+	if len(config.HMACSecret) != 0 && config.RSAPrivateKey != nil {
+		return nil, fmt.Errorf("HMACSecret and RSAPrivateKey are mutually exclusive")
+	}
+
+	return &Issuer{hmacSecret: config.HMACSecret, rsaKey: config.RSAPrivateKey}, nil
+}
+
+// Issue signs and returns a token granting rights for the given ttl.
+func (i *Issuer) Issue(rights map[string][]string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		Rights: rights,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	if i.rsaKey != nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		return token.SignedString(i.rsaKey)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(i.hmacSecret)
+}
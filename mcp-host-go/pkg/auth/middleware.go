@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// unauthorizedCode is the MCP/JSON-RPC error code used for both missing and
+// insufficient auth, distinguished by the surrounding HTTP status.
+const unauthorizedCode = -32001
+
+// rpcEnvelope is the subset of a JSON-RPC request this middleware needs in
+// order to decide whether the caller's claims permit the call.
+type rpcEnvelope struct {
+	Method string `json:"method"`
+	Params struct {
+		Name string `json:"name"`
+		URI  string `json:"uri"`
+	} `json:"params"`
+}
+
+// Middleware wraps next with bearer-token authentication and per-call
+// authorization. Requests without a valid token are rejected with 401; an
+// authenticated request whose JSON-RPC method/operation isn't covered by the
+// token's rights is rejected with 403.
+func Middleware(verifier *Verifier, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenString, ok := bearerToken(r)
+		if !ok {
+			writeUnauthorized(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+
+		claims, err := verifier.Verify(tokenString)
+		if err != nil {
+			writeUnauthorized(w, http.StatusUnauthorized, "invalid bearer token")
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				writeUnauthorized(w, http.StatusUnauthorized, "failed to read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			var envelope rpcEnvelope
+			if err := json.Unmarshal(body, &envelope); err != nil || envelope.Method == "" {
+				writeUnauthorized(w, http.StatusForbidden, "request body is not a recognizable JSON-RPC call")
+				return
+			}
+
+			operation := envelope.Params.Name
+			if operation == "" {
+				operation = envelope.Params.URI
+			}
+
+			if !claims.Allows(envelope.Method, operation) {
+				writeUnauthorized(w, http.StatusForbidden, "token does not permit this operation")
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(header, prefix), true
+}
+
+func writeUnauthorized(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"error": map[string]interface{}{
+			"code":    unauthorizedCode,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestMiddleware(t *testing.T, rights map[string][]string) (http.Handler, string) {
+	t.Helper()
+
+	secret := []byte("test-secret")
+	verifier, err := NewVerifier(VerifierConfig{HMACSecret: secret})
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+	issuer, err := NewIssuer(IssuerConfig{HMACSecret: secret})
+	if err != nil {
+		t.Fatalf("NewIssuer: %v", err)
+	}
+	token, err := issuer.Issue(rights, time.Minute)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	reached := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handler := Middleware(verifier, next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+		if reached {
+			w.Header().Set("X-Reached-Next", "true")
+		}
+	}), token
+}
+
+func doRequest(t *testing.T, handler http.Handler, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddleware_AllowsPermittedMethod(t *testing.T) {
+	handler, token := newTestMiddleware(t, map[string][]string{"tools/call": {"navigate_to"}})
+
+	rec := doRequest(t, handler, token, `{"jsonrpc":"2.0","method":"tools/call","params":{"name":"navigate_to"}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("X-Reached-Next") != "true" {
+		t.Fatalf("expected request to reach next handler")
+	}
+}
+
+func TestMiddleware_RejectsDisallowedMethod(t *testing.T) {
+	handler, token := newTestMiddleware(t, map[string][]string{"tools/call": {"navigate_to"}})
+
+	rec := doRequest(t, handler, token, `{"jsonrpc":"2.0","method":"tools/call","params":{"name":"cancel_job"}}`)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+// TestMiddleware_RejectsMalformedBody guards against the fail-open bug where
+// a body that doesn't parse into a JSON-RPC method skipped the scope check
+// entirely and fell through to next.ServeHTTP.
+func TestMiddleware_RejectsMalformedBody(t *testing.T) {
+	handler, token := newTestMiddleware(t, map[string][]string{"tools/call": {"navigate_to"}})
+
+	rec := doRequest(t, handler, token, `not json`)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for malformed body, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("X-Reached-Next") == "true" {
+		t.Fatalf("malformed body must not reach next handler")
+	}
+}
+
+// TestMiddleware_RejectsJSONRPCBatch guards against a top-level JSON array
+// (a JSON-RPC batch), which doesn't decode into rpcEnvelope, slipping past
+// the scope check instead of being rejected.
+func TestMiddleware_RejectsJSONRPCBatch(t *testing.T) {
+	handler, token := newTestMiddleware(t, map[string][]string{"tools/call": {"navigate_to"}})
+
+	rec := doRequest(t, handler, token, `[{"jsonrpc":"2.0","method":"tools/call","params":{"name":"navigate_to"}}]`)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for JSON-RPC batch body, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("X-Reached-Next") == "true" {
+		t.Fatalf("batch body must not reach next handler")
+	}
+}
+
+// TestMiddleware_RejectsEmptyMethod guards against an envelope with an
+// empty "method" field bypassing the scope check.
+func TestMiddleware_RejectsEmptyMethod(t *testing.T) {
+	handler, token := newTestMiddleware(t, map[string][]string{"tools/call": {"navigate_to"}})
+
+	rec := doRequest(t, handler, token, `{"jsonrpc":"2.0","method":"","params":{"name":"navigate_to"}}`)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for empty method, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// VerifierConfig configures a Verifier. Exactly one of HMACSecret or
+// RSAPublicKey must be set, selecting which algorithm the verifier accepts:
+// HS256 for HMACSecret, RS256 for RSAPublicKey.
+type VerifierConfig struct {
+	HMACSecret   []byte
+	RSAPublicKey *rsa.PublicKey
+}
+
+// Verifier validates bearer tokens presented to the SSE MCP endpoint.
+type Verifier struct {
+	hmacSecret []byte
+	rsaKey     *rsa.PublicKey
+}
+
+// NewVerifier creates a Verifier.
+func NewVerifier(config VerifierConfig) (*Verifier, error) {
+	if len(config.HMACSecret) == 0 && config.RSAPublicKey == nil {
+		return nil, fmt.Errorf("either HMACSecret or RSAPublicKey is required")
+	}
+	if len(config.HMACSecret) != 0 && config.RSAPublicKey != nil {
+		return nil, fmt.Errorf("HMACSecret and RSAPublicKey are mutually exclusive")
+	}
+
+	return &Verifier{hmacSecret: config.HMACSecret, rsaKey: config.RSAPublicKey}, nil
+}
+
+// Verify parses tokenString and returns its Claims if it is a well-formed,
+// unexpired token signed with this Verifier's key using the algorithm the
+// key was configured for.
+func (v *Verifier) Verify(tokenString string) (*Claims, error) {
+	validMethod := jwt.SigningMethodHS256.Alg()
+	if v.rsaKey != nil {
+		validMethod = jwt.SigningMethodRS256.Alg()
+	}
+
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if v.hmacSecret == nil {
+				return nil, fmt.Errorf("HS256 tokens are not accepted by this verifier")
+			}
+			return v.hmacSecret, nil
+		case *jwt.SigningMethodRSA:
+			if v.rsaKey == nil {
+				return nil, fmt.Errorf("RS256 tokens are not accepted by this verifier")
+			}
+			return v.rsaKey, nil
+		default:
+			return nil, fmt.Errorf("unsupported signing method: %v", t.Header["alg"])
+		}
+	}, jwt.WithValidMethods([]string{validMethod}))
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,223 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/chromedp"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// defaultChromedpRpcTimeout is used when a request doesn't specify one.
+const defaultChromedpRpcTimeout = 30 * time.Second
+
+// ChromedpDriver implements types.BrowserDriver by driving a headless
+// Chromium instance directly via github.com/chromedp/chromedp, standing in
+// for a connected browser extension in CI, servers, and containers where
+// installing the extension isn't practical.
+type ChromedpDriver struct {
+	logger      logger.Logger
+	allocCtx    context.Context
+	allocCancel context.CancelFunc
+	ctx         context.Context
+	cancel      context.CancelFunc
+}
+
+// ChromedpDriverConfig contains configuration for ChromedpDriver.
+type ChromedpDriverConfig struct {
+	Logger logger.Logger
+}
+
+// NewChromedpDriver launches a headless Chromium instance and returns a
+// driver for it.
+func NewChromedpDriver(config ChromedpDriverConfig) (*ChromedpDriver, error) {
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), chromedp.DefaultExecAllocatorOptions[:]...)
+	ctx, cancel := chromedp.NewContext(allocCtx)
+
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		allocCancel()
+		return nil, fmt.Errorf("failed to start headless chromium: %w", err)
+	}
+
+	return &ChromedpDriver{
+		logger:      config.Logger,
+		allocCtx:    allocCtx,
+		allocCancel: allocCancel,
+		ctx:         ctx,
+		cancel:      cancel,
+	}, nil
+}
+
+// RpcRequest dispatches the same RPC methods a browser extension would
+// handle, but executes them against the headless Chromium instance instead.
+// If options.Context is set, it's watched alongside the timeout so a caller
+// that cancels it (e.g. the jobs.Manager reacting to Cancel()) aborts the
+// in-flight chromedp call immediately rather than waiting out the timeout.
+func (d *ChromedpDriver) RpcRequest(request types.RpcRequest, options types.RpcOptions) (types.RpcResponse, error) {
+	timeout := defaultChromedpRpcTimeout
+	if options.Timeout > 0 {
+		timeout = time.Duration(options.Timeout) * time.Millisecond
+	}
+
+	ctx, cancel := context.WithTimeout(d.ctx, timeout)
+	defer cancel()
+
+	if options.Context != nil {
+		stop := context.AfterFunc(options.Context, cancel)
+		defer stop()
+	}
+
+	switch request.Method {
+	case "navigate_to":
+		return d.navigateTo(ctx, request.Params)
+	case "get_browser_state":
+		return d.getBrowserState(ctx)
+	case "get_dom_state":
+		return d.getDomState(ctx)
+	default:
+		return types.RpcResponse{}, fmt.Errorf("chromedp driver does not support method %q", request.Method)
+	}
+}
+
+func (d *ChromedpDriver) navigateTo(ctx context.Context, params interface{}) (types.RpcResponse, error) {
+	paramsMap, _ := params.(map[string]interface{})
+	url, _ := paramsMap["url"].(string)
+	if url == "" {
+		return types.RpcResponse{}, fmt.Errorf("url is required")
+	}
+
+	var finalURL string
+	if err := chromedp.Run(ctx,
+		chromedp.Navigate(url),
+		chromedp.Location(&finalURL),
+	); err != nil {
+		return types.RpcResponse{}, fmt.Errorf("chromedp navigation failed: %w", err)
+	}
+
+	return types.RpcResponse{
+		Result: map[string]interface{}{
+			"finalUrl":      finalURL,
+			"waitCondition": "load",
+		},
+	}, nil
+}
+
+func (d *ChromedpDriver) getBrowserState(ctx context.Context) (types.RpcResponse, error) {
+	var title, url string
+	if err := chromedp.Run(ctx,
+		chromedp.Title(&title),
+		chromedp.Location(&url),
+	); err != nil {
+		return types.RpcResponse{}, fmt.Errorf("chromedp state read failed: %w", err)
+	}
+
+	return types.RpcResponse{
+		Result: map[string]interface{}{
+			"url":   url,
+			"title": title,
+		},
+	}, nil
+}
+
+// domStateScript collects every interactive element (button, input, link,
+// select, textarea, and anything exposing role="button" or an onclick
+// handler) from the current page into the same shape the browser
+// extension's get_dom_state RPC returns (formattedDom/interactiveElements/
+// meta), so DOM-reading tools and resources work the same way against this
+// fallback as they do against a real extension.
+const domStateScript = `
+(() => {
+	const nodes = Array.from(document.querySelectorAll(
+		'button, input, a[href], select, textarea, [role="button"], [onclick]'
+	));
+
+	function cssPath(el) {
+		if (el.id) return '#' + el.id;
+		const parts = [];
+		let node = el;
+		while (node && node.nodeType === 1 && parts.length < 8) {
+			let part = node.tagName.toLowerCase();
+			const parent = node.parentElement;
+			if (parent) {
+				const siblings = Array.from(parent.children).filter((c) => c.tagName === node.tagName);
+				if (siblings.length > 1) {
+					part += ':nth-of-type(' + (siblings.indexOf(node) + 1) + ')';
+				}
+			}
+			parts.unshift(part);
+			node = node.parentElement;
+		}
+		return parts.join(' > ');
+	}
+
+	function xpath(el) {
+		if (el.id) return '//*[@id="' + el.id + '"]';
+		const parts = [];
+		let node = el;
+		while (node && node.nodeType === 1) {
+			let index = 1;
+			let sibling = node.previousElementSibling;
+			while (sibling) {
+				if (sibling.tagName === node.tagName) index++;
+				sibling = sibling.previousElementSibling;
+			}
+			parts.unshift(node.tagName.toLowerCase() + '[' + index + ']');
+			node = node.parentElement;
+		}
+		return '/' + parts.join('/');
+	}
+
+	function isVisible(el) {
+		const rect = el.getBoundingClientRect();
+		const style = window.getComputedStyle(el);
+		return rect.width > 0 && rect.height > 0 && style.visibility !== 'hidden' && style.display !== 'none';
+	}
+
+	const interactiveElements = nodes.map((el, index) => ({
+		index: index,
+		tagName: el.tagName.toLowerCase(),
+		type: el.getAttribute('type') || el.tagName.toLowerCase(),
+		text: (el.innerText || el.value || '').trim().slice(0, 200),
+		id: el.id || '',
+		class: typeof el.className === 'string' ? el.className : '',
+		href: el.getAttribute('href') || '',
+		value: el.value || '',
+		placeholder: el.getAttribute('placeholder') || '',
+		selector: cssPath(el),
+		xpath: xpath(el),
+		visible: isVisible(el),
+	}));
+
+	return {
+		formattedDom: document.documentElement.outerHTML.slice(0, 20000),
+		interactiveElements: interactiveElements,
+		meta: { url: document.location.href, title: document.title },
+	};
+})()
+`
+
+// getDomState evaluates domStateScript against the current page, standing
+// in for the browser extension's get_dom_state RPC.
+func (d *ChromedpDriver) getDomState(ctx context.Context) (types.RpcResponse, error) {
+	var result map[string]interface{}
+	if err := chromedp.Run(ctx, chromedp.Evaluate(domStateScript, &result)); err != nil {
+		return types.RpcResponse{}, fmt.Errorf("chromedp DOM state read failed: %w", err)
+	}
+
+	return types.RpcResponse{Result: result}, nil
+}
+
+// Close shuts down the headless Chromium instance.
+func (d *ChromedpDriver) Close() error {
+	d.cancel()
+	d.allocCancel()
+	return nil
+}
@@ -0,0 +1,74 @@
+package browser
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// defaultHandshakeTimeout is how long Resolve waits for the extension to
+// answer a ping before falling back to a headless driver.
+const defaultHandshakeTimeout = 5 * time.Second
+
+// DriverManagerConfig contains configuration for DriverManager.
+type DriverManagerConfig struct {
+	Logger    logger.Logger
+	Messaging types.Messaging
+
+	// HandshakeTimeout bounds how long Resolve waits for the extension to
+	// respond before falling back to a headless chromedp driver. Defaults
+	// to defaultHandshakeTimeout if zero.
+	HandshakeTimeout time.Duration
+}
+
+// DriverManager picks the types.BrowserDriver the rest of the host should
+// use for the duration of its lifetime: the connected browser extension if
+// one answers in time, otherwise an auto-started headless Chromium.
+type DriverManager struct {
+	logger           logger.Logger
+	messaging        types.Messaging
+	handshakeTimeout time.Duration
+}
+
+// NewDriverManager creates a DriverManager.
+func NewDriverManager(config DriverManagerConfig) (*DriverManager, error) {
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+
+	if config.Messaging == nil {
+		return nil, fmt.Errorf("messaging is required")
+	}
+
+	handshakeTimeout := config.HandshakeTimeout
+	if handshakeTimeout <= 0 {
+		handshakeTimeout = defaultHandshakeTimeout
+	}
+
+	return &DriverManager{
+		logger:           config.Logger,
+		messaging:        config.Messaging,
+		handshakeTimeout: handshakeTimeout,
+	}, nil
+}
+
+// Resolve pings the extension over Native Messaging; if it answers within
+// HandshakeTimeout, it returns a driver routed to that extension. Otherwise
+// it starts a headless Chromium instance and returns a driver for that,
+// so tool calls still work in CI, servers, and containers without an
+// extension installed.
+func (m *DriverManager) Resolve() (types.BrowserDriver, error) {
+	timeoutMs := int(m.handshakeTimeout / time.Millisecond)
+	resp, err := m.messaging.RpcRequest(types.RpcRequest{Method: "ping"}, types.RpcOptions{Timeout: timeoutMs})
+	if err == nil && resp.Error == nil {
+		m.logger.Info("Extension handshake detected, using native messaging driver")
+		return NewNativeMessagingDriver(m.messaging)
+	}
+
+	m.logger.Info("No extension handshake within timeout, falling back to headless chromedp driver", zap.Duration("timeout", m.handshakeTimeout))
+	return NewChromedpDriver(ChromedpDriverConfig{Logger: m.logger})
+}
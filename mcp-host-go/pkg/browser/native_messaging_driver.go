@@ -0,0 +1,36 @@
+// Package browser abstracts how the host talks to an actual browser behind
+// types.BrowserDriver, so tools and resources that need to drive navigation
+// or read page state don't have to know whether they're going through a
+// connected Chrome extension or a headless Chromium the host started
+// itself.
+package browser
+
+import (
+	"fmt"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// NativeMessagingDriver implements types.BrowserDriver on top of the
+// existing Native Messaging RPC channel to a connected browser extension.
+type NativeMessagingDriver struct {
+	messaging types.Messaging
+}
+
+// NewNativeMessagingDriver creates a NativeMessagingDriver.
+func NewNativeMessagingDriver(messaging types.Messaging) (*NativeMessagingDriver, error) {
+	if messaging == nil {
+		return nil, fmt.Errorf("messaging is required")
+	}
+
+	return &NativeMessagingDriver{messaging: messaging}, nil
+}
+
+// RpcRequest forwards the request to the connected extension unchanged.
+// options.Context, if set, isn't currently honored here: unlike the
+// headless chromedp driver, there's no way to interrupt a native-messaging
+// request already written to the pipe, only to time out waiting on its
+// response.
+func (d *NativeMessagingDriver) RpcRequest(request types.RpcRequest, options types.RpcOptions) (types.RpcResponse, error) {
+	return d.messaging.RpcRequest(request, options)
+}
@@ -0,0 +1,210 @@
+// Package domcache caches the parsed DOM-state snapshot for a tab so an SSE
+// client paging through hundreds of elements only pays for one
+// get_dom_state round trip (and one JSON parse) per revision, instead of
+// one per page. Eviction is size-aware and memory-pressure-driven, similar
+// in spirit to Hugo's dynacache: expensive parsed objects are dropped and
+// rebuilt lazily rather than held forever.
+package domcache
+
+import (
+	"container/list"
+	"encoding/json"
+	"runtime"
+	"sync"
+)
+
+// defaultMaxBytes bounds total cached snapshot size if the caller doesn't
+// configure one explicitly.
+const defaultMaxBytes = 64 * 1024 * 1024
+
+// defaultHeapCeilingBytes is the soft memory-pressure threshold: once the
+// process's heap grows past this, Put evicts more aggressively than the
+// byte budget alone would require.
+const defaultHeapCeilingBytes = 512 * 1024 * 1024
+
+type entry struct {
+	key      string
+	data     []byte // JSON-encoded DomStateData snapshot
+	revision int64
+	size     int64
+	elem     *list.Element
+}
+
+// Cache is a size-aware LRU of parsed DOM-state snapshots keyed by tab/URI,
+// with a monotonically increasing revision per key so pagination reads can
+// detect whether the page they're reading is still current.
+type Cache struct {
+	mu        sync.Mutex
+	entries   map[string]*entry
+	order     *list.List
+	revisions map[string]int64
+
+	maxBytes         int64
+	heapCeilingBytes int64
+	usedBytes        int64
+
+	hits   uint64
+	misses uint64
+}
+
+// Config configures a Cache.
+type Config struct {
+	// MaxBytes bounds total cached snapshot size. Defaults to
+	// defaultMaxBytes if zero.
+	MaxBytes int64
+
+	// HeapCeilingBytes is the soft memory-pressure threshold above which
+	// Put evicts down to half the normal budget instead of just enough to
+	// fit the new entry. Defaults to defaultHeapCeilingBytes if zero.
+	HeapCeilingBytes int64
+}
+
+// New creates a Cache.
+func New(config Config) *Cache {
+	maxBytes := config.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBytes
+	}
+	heapCeiling := config.HeapCeilingBytes
+	if heapCeiling <= 0 {
+		heapCeiling = defaultHeapCeilingBytes
+	}
+
+	return &Cache{
+		entries:          make(map[string]*entry),
+		order:            list.New(),
+		revisions:        make(map[string]int64),
+		maxBytes:         maxBytes,
+		heapCeilingBytes: heapCeiling,
+	}
+}
+
+// Invalidate bumps key's revision, so any entry cached for it is treated as
+// stale on the next Get. It returns the new revision.
+func (c *Cache) Invalidate(key string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.revisions[key]++
+	return c.revisions[key]
+}
+
+// CurrentRevision returns the latest revision NotifyStateChange has bumped
+// key to, or 0 if it has never changed.
+func (c *Cache) CurrentRevision(key string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.revisions[key]
+}
+
+// Get returns the cached snapshot for key if one exists and is still at the
+// current revision. revisionSet additionally pins the read to
+// requestedRevision rather than "whatever is newest" — used so an SSE client
+// can detect it has drifted rather than silently skipping pages. Revision 0
+// is a legitimate, reachable revision (the state before anything has
+// invalidated key), so callers must signal "pin requested" via revisionSet
+// rather than via requestedRevision != 0.
+func (c *Cache) Get(key string, revisionSet bool, requestedRevision int64, out interface{}) (revision int64, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, exists := c.entries[key]
+	if !exists {
+		c.misses++
+		return 0, false
+	}
+
+	current := c.revisions[key]
+	if e.revision != current {
+		c.misses++
+		return 0, false
+	}
+
+	if revisionSet && requestedRevision != e.revision {
+		c.misses++
+		return 0, false
+	}
+
+	if err := json.Unmarshal(e.data, out); err != nil {
+		c.misses++
+		return 0, false
+	}
+
+	c.order.MoveToFront(e.elem)
+	c.hits++
+	return e.revision, true
+}
+
+// Put stores data as the snapshot for key at its current revision,
+// evicting other entries as needed to respect the byte budget (and more
+// aggressively if the process is under memory pressure).
+func (c *Cache) Put(key string, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing, exists := c.entries[key]; exists {
+		c.usedBytes -= existing.size
+		c.order.Remove(existing.elem)
+		delete(c.entries, key)
+	}
+
+	e := &entry{key: key, data: body, revision: c.revisions[key], size: int64(len(body))}
+	e.elem = c.order.PushFront(e)
+	c.entries[key] = e
+	c.usedBytes += e.size
+
+	c.evictLocked()
+	return nil
+}
+
+// evictLocked drops least-recently-used entries until usedBytes is back
+// under budget. Under memory pressure (heap alloc past HeapCeilingBytes)
+// it evicts down to half the normal budget instead, so a spike in process
+// memory sheds cache before it forces a GC-driven slowdown elsewhere.
+func (c *Cache) evictLocked() {
+	budget := c.maxBytes
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	if int64(mem.HeapAlloc) > c.heapCeilingBytes {
+		budget = c.maxBytes / 2
+	}
+
+	for c.usedBytes > budget {
+		back := c.order.Back()
+		if back == nil {
+			break
+		}
+		e := back.Value.(*entry)
+		c.order.Remove(back)
+		delete(c.entries, e.key)
+		c.usedBytes -= e.size
+	}
+}
+
+// Stats reports cache hit/miss counters and current byte usage.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	UsedBytes int64
+	MaxBytes  int64
+}
+
+// Stats returns a snapshot of the cache's metrics.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Stats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		UsedBytes: c.usedBytes,
+		MaxBytes:  c.maxBytes,
+	}
+}
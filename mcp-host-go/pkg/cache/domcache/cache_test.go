@@ -0,0 +1,48 @@
+package domcache
+
+import "testing"
+
+func TestCache_GetWithoutRevisionSetReturnsLatestEvenWhenLatestIsZero(t *testing.T) {
+	c := New(Config{})
+
+	if err := c.Put("k", map[string]string{"a": "b"}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	var out map[string]string
+	revision, ok := c.Get("k", false, 0, &out)
+	if !ok {
+		t.Fatalf("expected a hit for an unpinned read against revision 0")
+	}
+	if revision != 0 {
+		t.Fatalf("expected revision 0, got %d", revision)
+	}
+}
+
+func TestCache_GetPinnedToRevisionZeroMatchesOnlyRevisionZero(t *testing.T) {
+	c := New(Config{})
+
+	if err := c.Put("k", map[string]string{"a": "b"}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	var out map[string]string
+	if _, ok := c.Get("k", true, 0, &out); !ok {
+		t.Fatalf("expected a hit when explicitly pinned to the current revision 0")
+	}
+
+	c.Invalidate("k")
+	if err := c.Put("k", map[string]string{"a": "c"}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	// The cache has moved on to revision 1; a read still pinned to revision
+	// 0 must miss rather than silently being treated as "no pin".
+	if _, ok := c.Get("k", true, 0, &out); ok {
+		t.Fatalf("expected a miss: pinned to stale revision 0, cache is now at revision 1")
+	}
+
+	if _, ok := c.Get("k", false, 0, &out); !ok {
+		t.Fatalf("expected a hit for an unpinned read against the new revision")
+	}
+}
@@ -0,0 +1,39 @@
+// Package jobs implements a small bounded work queue for long-running
+// browser operations (currently just navigation) that shouldn't tie up a
+// synchronous tool call. Jobs are journaled to disk so pending work
+// survives a host restart.
+package jobs
+
+// Status is the lifecycle state of a Job.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Job is a single unit of queued work and its current state.
+type Job struct {
+	ID        string                 `json:"id"`
+	Method    string                 `json:"method"`
+	Params    map[string]interface{} `json:"params"`
+	Status    Status                 `json:"status"`
+	Result    interface{}            `json:"result,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+	CreatedAt int64                  `json:"createdAt"`
+	UpdatedAt int64                  `json:"updatedAt"`
+}
+
+// IsTerminal reports whether the job has finished and will never change
+// status again.
+func (j Job) IsTerminal() bool {
+	switch j.Status {
+	case StatusSucceeded, StatusFailed, StatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,390 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+const (
+	defaultQueueCapacity  = 256
+	defaultTabConcurrency = 2
+	defaultJobCallTimeout = 120 * time.Second
+)
+
+// ResourceRegistrar is the subset of mcp.Server a Manager needs in order to
+// publish and retire a browser://jobs/{id} resource per job.
+type ResourceRegistrar interface {
+	RegisterResource(resource types.Resource) error
+	UnregisterResource(uri string)
+}
+
+// ManagerConfig contains configuration for Manager.
+type ManagerConfig struct {
+	Logger logger.Logger
+	Driver types.BrowserDriver
+
+	// Dir is where the job journal is persisted.
+	Dir string
+
+	// Messaging, if set, is used to push notifications/resources/updated
+	// for a job's browser://jobs/{id} resource when a client has
+	// subscribed to it.
+	Messaging types.Messaging
+
+	// Registrar, if set, gets a browser://jobs/{id} resource registered for
+	// every submitted or replayed job. Jobs are never evicted, so these
+	// resources stay registered for the Manager's lifetime.
+	Registrar ResourceRegistrar
+
+	// QueueCapacity bounds how many jobs may be queued at once; Submit
+	// returns an error once it's full. Defaults to defaultQueueCapacity.
+	QueueCapacity int
+
+	// TabConcurrency bounds how many jobs for the same tab may run at
+	// once. Defaults to defaultTabConcurrency.
+	TabConcurrency int
+
+	// CallTimeout bounds how long a single driver RPC call may run before
+	// it's treated as timed out. Defaults to defaultJobCallTimeout.
+	CallTimeout time.Duration
+}
+
+type job struct {
+	Job
+	cancel context.CancelFunc
+
+	// cancelled is set by Cancel(), under the same lock as cancel(), so the
+	// worker that observes ctx.Done() can tell an explicit cancellation
+	// apart from the context's own timeout expiring.
+	cancelled bool
+}
+
+// Manager runs a bounded, disk-journaled queue of browser jobs (today just
+// navigation), so a caller can fire-and-forget a long-running navigation
+// and poll or subscribe for its outcome instead of blocking a tool call.
+type Manager struct {
+	logger    logger.Logger
+	driver    types.BrowserDriver
+	messaging types.Messaging
+	store     *store
+
+	registrar ResourceRegistrar
+
+	queueCapacity  int
+	tabConcurrency int
+	callTimeout    time.Duration
+
+	mu        sync.Mutex
+	jobs      map[string]*job
+	resources map[string]*jobResource
+	tabSlots  map[string]int
+
+	pending chan string
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewManager creates a Manager, replaying any jobs persisted from a prior
+// run and re-enqueueing the ones that hadn't finished yet.
+func NewManager(config ManagerConfig) (*Manager, error) {
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if config.Driver == nil {
+		return nil, fmt.Errorf("driver is required")
+	}
+	if config.Dir == "" {
+		return nil, fmt.Errorf("dir is required")
+	}
+
+	queueCapacity := config.QueueCapacity
+	if queueCapacity <= 0 {
+		queueCapacity = defaultQueueCapacity
+	}
+	tabConcurrency := config.TabConcurrency
+	if tabConcurrency <= 0 {
+		tabConcurrency = defaultTabConcurrency
+	}
+	callTimeout := config.CallTimeout
+	if callTimeout <= 0 {
+		callTimeout = defaultJobCallTimeout
+	}
+
+	s, err := newStore(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{
+		logger:         config.Logger,
+		driver:         config.Driver,
+		messaging:      config.Messaging,
+		store:          s,
+		registrar:      config.Registrar,
+		queueCapacity:  queueCapacity,
+		tabConcurrency: tabConcurrency,
+		callTimeout:    callTimeout,
+		jobs:           make(map[string]*job),
+		resources:      make(map[string]*jobResource),
+		tabSlots:       make(map[string]int),
+		pending:        make(chan string, queueCapacity),
+		stopCh:         make(chan struct{}),
+	}
+
+	persisted, err := s.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, j := range persisted {
+		if j.Status == StatusRunning {
+			// Interrupted mid-run by the restart; run() only picks up
+			// queued jobs, so put it back in line.
+			j.Status = StatusQueued
+		}
+		m.jobs[j.ID] = &job{Job: j}
+		m.registerJobResource(j.ID)
+		if !j.IsTerminal() {
+			m.pending <- j.ID
+		}
+	}
+
+	for i := 0; i < tabConcurrency*4; i++ {
+		m.wg.Add(1)
+		go m.worker()
+	}
+
+	return m, nil
+}
+
+// Submit enqueues a navigation job and returns its ID immediately.
+func (m *Manager) Submit(method string, params map[string]interface{}) (string, error) {
+	now := time.Now().UnixMilli()
+	j := &job{Job: Job{
+		ID:        uuid.NewString(),
+		Method:    method,
+		Params:    params,
+		Status:    StatusQueued,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}}
+
+	m.mu.Lock()
+	m.jobs[j.ID] = j
+	m.mu.Unlock()
+
+	if err := m.store.append(j.Job); err != nil {
+		return "", err
+	}
+	m.registerJobResource(j.ID)
+
+	select {
+	case m.pending <- j.ID:
+	default:
+		return "", fmt.Errorf("job queue is full (capacity %d)", m.queueCapacity)
+	}
+
+	return j.ID, nil
+}
+
+// Get returns the current state of a job.
+func (m *Manager) Get(id string) (Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return j.Job, true
+}
+
+// Cancel stops a job: a queued job is marked cancelled without ever
+// running; a running job has its context cancelled and passed to the
+// driver via types.RpcOptions.Context, so a transport that honors it (the
+// headless Chromium driver does) aborts the in-flight call as soon as it
+// next checks ctx, instead of running to completion in the background
+// after Cancel returns.
+func (m *Manager) Cancel(id string) error {
+	m.mu.Lock()
+	j, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return fmt.Errorf("job not found: %s", id)
+	}
+	if j.IsTerminal() {
+		m.mu.Unlock()
+		return fmt.Errorf("job %s already finished with status %s", id, j.Status)
+	}
+
+	running := j.Status == StatusRunning
+	cancel := j.cancel
+	if running {
+		j.cancelled = true
+	}
+	m.mu.Unlock()
+
+	if running {
+		if cancel != nil {
+			cancel()
+		}
+		return nil
+	}
+
+	m.transition(id, StatusCancelled, nil, "")
+	return nil
+}
+
+// Shutdown stops accepting new work and waits for in-flight jobs to notice
+// cancellation and exit.
+func (m *Manager) Shutdown() error {
+	close(m.stopCh)
+	m.wg.Wait()
+	return m.store.close()
+}
+
+func (m *Manager) worker() {
+	defer m.wg.Done()
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case id := <-m.pending:
+			m.run(id)
+		}
+	}
+}
+
+func (m *Manager) run(id string) {
+	m.mu.Lock()
+	j, ok := m.jobs[id]
+	if !ok || j.Status != StatusQueued {
+		m.mu.Unlock()
+		return
+	}
+
+	tab := tabKeyFor(j.Params)
+	if m.tabSlots[tab] >= m.tabConcurrency {
+		m.mu.Unlock()
+		// Tab is busy; park this job back on the queue and let another
+		// worker pick up something else in the meantime.
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			m.pending <- id
+		}()
+		return
+	}
+	m.tabSlots[tab]++
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.callTimeout)
+	j.cancel = cancel
+	m.mu.Unlock()
+
+	m.transition(id, StatusRunning, nil, "")
+
+	resultCh := make(chan types.RpcResponse, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		// The tab slot and ctx are only released once the driver call
+		// itself returns, not as soon as run() stops waiting on it —
+		// otherwise a second job for the same tab could start while this
+		// one is still actually in flight, defeating TabConcurrency.
+		defer cancel()
+		defer func() {
+			m.mu.Lock()
+			m.tabSlots[tab]--
+			m.mu.Unlock()
+		}()
+
+		resp, err := m.driver.RpcRequest(types.RpcRequest{Method: j.Method, Params: j.Params}, types.RpcOptions{
+			Timeout: int(m.callTimeout / time.Millisecond),
+			Context: ctx,
+		})
+		if err != nil {
+			errCh <- err
+			return
+		}
+		resultCh <- resp
+	}()
+
+	select {
+	case <-ctx.Done():
+		m.mu.Lock()
+		explicitlyCancelled := j.cancelled
+		m.mu.Unlock()
+		if explicitlyCancelled {
+			m.transition(id, StatusCancelled, nil, "job was cancelled")
+		} else {
+			m.transition(id, StatusFailed, nil, fmt.Sprintf("job timed out after %s", m.callTimeout))
+		}
+	case err := <-errCh:
+		m.logger.Error("Job failed", zap.String("jobID", id), zap.Error(err))
+		m.transition(id, StatusFailed, nil, err.Error())
+	case resp := <-resultCh:
+		if resp.Error != nil {
+			m.transition(id, StatusFailed, nil, resp.Error.Message)
+		} else {
+			m.transition(id, StatusSucceeded, resp.Result, "")
+		}
+	}
+}
+
+func (m *Manager) transition(id string, status Status, result interface{}, errMsg string) {
+	m.mu.Lock()
+	j, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	j.Status = status
+	j.Result = result
+	j.Error = errMsg
+	j.UpdatedAt = time.Now().UnixMilli()
+	snapshot := j.Job
+	m.mu.Unlock()
+
+	if err := m.store.append(snapshot); err != nil {
+		m.logger.Error("Failed to persist job transition", zap.String("jobID", id), zap.Error(err))
+	}
+
+	m.mu.Lock()
+	resource := m.resources[id]
+	m.mu.Unlock()
+	if resource != nil {
+		resource.notify()
+	}
+}
+
+func (m *Manager) registerJobResource(id string) {
+	resource, err := newJobResource(id, m, m.messaging)
+	if err != nil {
+		m.logger.Error("Failed to create job resource", zap.String("jobID", id), zap.Error(err))
+		return
+	}
+
+	m.mu.Lock()
+	m.resources[id] = resource
+	m.mu.Unlock()
+
+	if m.registrar == nil {
+		return
+	}
+	if err := m.registrar.RegisterResource(resource); err != nil {
+		m.logger.Debug("Job resource already registered", zap.String("jobID", id))
+	}
+}
+
+func tabKeyFor(params map[string]interface{}) string {
+	if tabID, ok := params["tab_id"].(string); ok && tabID != "" {
+		return tabID
+	}
+	return "default"
+}
@@ -0,0 +1,191 @@
+package jobs
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+type testLogger struct{}
+
+func (testLogger) Error(string, ...interface{}) {}
+func (testLogger) Warn(string, ...interface{})  {}
+func (testLogger) Info(string, ...interface{})  {}
+func (testLogger) Debug(string, ...interface{}) {}
+
+func (l testLogger) With(...logger.Field) logger.Logger { return l }
+
+// blockingDriver tracks how many calls per tab are concurrently in flight,
+// failing the test immediately if it ever sees more than one for the same
+// tab. Calls block on a release channel (ignoring ctx, to mirror a
+// transport like native messaging that can't actually abort an in-flight
+// call) until the test closes it.
+type blockingDriver struct {
+	mu       sync.Mutex
+	inFlight map[string]int
+	maxSeen  map[string]int
+	release  chan struct{}
+	t        *testing.T
+}
+
+func newBlockingDriver(t *testing.T) *blockingDriver {
+	return &blockingDriver{
+		inFlight: make(map[string]int),
+		maxSeen:  make(map[string]int),
+		release:  make(chan struct{}),
+		t:        t,
+	}
+}
+
+func (d *blockingDriver) RpcRequest(request types.RpcRequest, options types.RpcOptions) (types.RpcResponse, error) {
+	tab, _ := request.Params["tab_id"].(string)
+
+	d.mu.Lock()
+	d.inFlight[tab]++
+	if d.inFlight[tab] > d.maxSeen[tab] {
+		d.maxSeen[tab] = d.inFlight[tab]
+	}
+	if d.inFlight[tab] > 1 {
+		d.t.Errorf("concurrent RpcRequest calls for tab %q: %d in flight", tab, d.inFlight[tab])
+	}
+	d.mu.Unlock()
+
+	<-d.release
+
+	d.mu.Lock()
+	d.inFlight[tab]--
+	d.mu.Unlock()
+
+	return types.RpcResponse{Result: "ok"}, nil
+}
+
+func TestManager_CancelDoesNotAllowConcurrentRunForSameTab(t *testing.T) {
+	driver := newBlockingDriver(t)
+	mgr, err := NewManager(ManagerConfig{
+		Logger:         testLogger{},
+		Driver:         driver,
+		Dir:            t.TempDir(),
+		TabConcurrency: 1,
+		CallTimeout:    time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	defer mgr.Shutdown()
+
+	id1, err := mgr.Submit("slow", map[string]interface{}{"tab_id": "t1"})
+	if err != nil {
+		t.Fatalf("Submit job1: %v", err)
+	}
+
+	waitForStatus(t, mgr, id1, StatusRunning)
+
+	if err := mgr.Cancel(id1); err != nil {
+		t.Fatalf("Cancel job1: %v", err)
+	}
+	waitForStatus(t, mgr, id1, StatusCancelled)
+
+	id2, err := mgr.Submit("slow", map[string]interface{}{"tab_id": "t1"})
+	if err != nil {
+		t.Fatalf("Submit job2: %v", err)
+	}
+
+	// job2 must stay queued: job1's driver call is still in flight (blocked
+	// on driver.release) even though job1 itself already transitioned to
+	// Cancelled, so the tab slot must not have been freed yet.
+	time.Sleep(150 * time.Millisecond)
+	job2, _ := mgr.Get(id2)
+	if job2.Status != StatusQueued {
+		t.Fatalf("expected job2 to still be queued while job1's call is in flight, got %s", job2.Status)
+	}
+
+	close(driver.release)
+
+	waitForStatus(t, mgr, id2, StatusSucceeded)
+
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	if driver.maxSeen["t1"] > 1 {
+		t.Fatalf("observed %d concurrent calls for tab t1, want at most 1", driver.maxSeen["t1"])
+	}
+}
+
+func TestManager_ExplicitCancelReportsCancelledNotTimedOut(t *testing.T) {
+	driver := newBlockingDriver(t)
+	mgr, err := NewManager(ManagerConfig{
+		Logger:         testLogger{},
+		Driver:         driver,
+		Dir:            t.TempDir(),
+		TabConcurrency: 1,
+		CallTimeout:    time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	defer func() {
+		close(driver.release)
+		mgr.Shutdown()
+	}()
+
+	id, err := mgr.Submit("slow", map[string]interface{}{"tab_id": "t2"})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	waitForStatus(t, mgr, id, StatusRunning)
+
+	if err := mgr.Cancel(id); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	waitForStatus(t, mgr, id, StatusCancelled)
+
+	job, _ := mgr.Get(id)
+	if job.Error != "job was cancelled" {
+		t.Fatalf("expected cancellation message, got %q", job.Error)
+	}
+}
+
+func TestManager_TimeoutReportsFailedNotCancelled(t *testing.T) {
+	driver := newBlockingDriver(t)
+	mgr, err := NewManager(ManagerConfig{
+		Logger:         testLogger{},
+		Driver:         driver,
+		Dir:            t.TempDir(),
+		TabConcurrency: 1,
+		CallTimeout:    30 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	defer func() {
+		close(driver.release)
+		mgr.Shutdown()
+	}()
+
+	id, err := mgr.Submit("slow", map[string]interface{}{"tab_id": "t3"})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	waitForStatus(t, mgr, id, StatusFailed)
+
+	job, _ := mgr.Get(id)
+	if job.Status != StatusFailed {
+		t.Fatalf("expected StatusFailed for a timed-out job, got %s", job.Status)
+	}
+}
+
+func waitForStatus(t *testing.T, mgr *Manager, id string, want Status) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if j, ok := mgr.Get(id); ok && j.Status == want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	j, _ := mgr.Get(id)
+	t.Fatalf("timed out waiting for job %s to reach status %s, last seen %s", id, want, j.Status)
+}
@@ -0,0 +1,97 @@
+package jobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// jobResource exposes a single Job as an MCP resource at
+// browser://jobs/{id}, so an agent can either poll Read() or subscribe for
+// a push notification on every queued/running/succeeded/failed/cancelled
+// transition instead of polling get_job_status in a loop.
+type jobResource struct {
+	id        string
+	uri       string
+	manager   *Manager
+	messaging types.Messaging
+
+	mu         sync.Mutex
+	subscribed bool
+}
+
+func newJobResource(id string, manager *Manager, messaging types.Messaging) (*jobResource, error) {
+	if manager == nil {
+		return nil, fmt.Errorf("manager is required")
+	}
+
+	return &jobResource{
+		id:        id,
+		uri:       "browser://jobs/" + id,
+		manager:   manager,
+		messaging: messaging,
+	}, nil
+}
+
+func (r *jobResource) GetURI() string         { return r.uri }
+func (r *jobResource) GetName() string        { return "Job " + r.id }
+func (r *jobResource) GetMimeType() string    { return "application/json" }
+func (r *jobResource) GetDescription() string { return "State of async browser job " + r.id }
+
+// Read returns the job's current state as JSON.
+func (r *jobResource) Read() (types.ResourceContent, error) {
+	job, ok := r.manager.Get(r.id)
+	if !ok {
+		return types.ResourceContent{}, fmt.Errorf("job not found: %s", r.id)
+	}
+
+	body, err := json.Marshal(job)
+	if err != nil {
+		return types.ResourceContent{}, fmt.Errorf("failed to marshal job: %w", err)
+	}
+
+	return types.ResourceContent{
+		Contents: []types.ResourceItem{
+			{URI: r.uri, MimeType: r.GetMimeType(), Text: string(body)},
+		},
+	}, nil
+}
+
+// Subscribe starts pushing a notification on every status transition.
+func (r *jobResource) Subscribe() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribed = true
+	return nil
+}
+
+// Unsubscribe stops pushing transition notifications.
+func (r *jobResource) Unsubscribe() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribed = false
+	return nil
+}
+
+// notify pushes the job's current state if a subscriber is listening.
+func (r *jobResource) notify() {
+	r.mu.Lock()
+	subscribed := r.subscribed
+	r.mu.Unlock()
+
+	if !subscribed || r.messaging == nil {
+		return
+	}
+
+	content, err := r.Read()
+	if err != nil {
+		return
+	}
+
+	r.messaging.SendNotification("notifications/resources/updated", map[string]interface{}{
+		"uri":      r.uri,
+		"contents": content.Contents,
+	})
+}
@@ -0,0 +1,95 @@
+package jobs
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// store is an append-only JSON-lines journal of Job snapshots. Replaying it
+// and keeping the last record per ID reconstructs the queue's state across
+// restarts; it is not a key-value store, just a crash-safe write-ahead log.
+type store struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newStore(dir string) (*store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create jobs directory: %w", err)
+	}
+
+	path := filepath.Join(dir, "jobs.jsonl")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open jobs journal: %w", err)
+	}
+
+	return &store{file: file}, nil
+}
+
+// append writes the job's current state as a new journal line.
+func (s *store) append(job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job: %w", err)
+	}
+	line = append(line, '\n')
+
+	if _, err := s.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write job journal entry: %w", err)
+	}
+	return nil
+}
+
+// loadAll replays the journal, returning the most recent snapshot of every
+// job ID it has ever seen, in the order each ID first appeared.
+func (s *store) loadAll() ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Seek(0, 0); err != nil {
+		return nil, fmt.Errorf("failed to seek jobs journal: %w", err)
+	}
+
+	latest := make(map[string]Job)
+	var order []string
+
+	scanner := bufio.NewScanner(s.file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var job Job
+		if err := json.Unmarshal(scanner.Bytes(), &job); err != nil {
+			continue // skip a partially-written last line from a crash mid-write
+		}
+		if _, seen := latest[job.ID]; !seen {
+			order = append(order, job.ID)
+		}
+		latest[job.ID] = job
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read jobs journal: %w", err)
+	}
+
+	if _, err := s.file.Seek(0, 2); err != nil {
+		return nil, fmt.Errorf("failed to seek jobs journal: %w", err)
+	}
+
+	jobs := make([]Job, 0, len(order))
+	for _, id := range order {
+		jobs = append(jobs, latest[id])
+	}
+	return jobs, nil
+}
+
+func (s *store) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+)
+
+// ConsoleSink writes log lines to stderr, colorizing the level when stderr
+// is a terminal and leaving it plain otherwise (e.g. when redirected to a
+// file or captured by a process supervisor).
+type ConsoleSink struct {
+	format  Format
+	out     io.Writer
+	isTTY   bool
+	mu      sync.Mutex
+	colorFn map[LogLevel]func(format string, a ...interface{}) string
+}
+
+// NewConsoleSink creates a ConsoleSink writing to stderr.
+func NewConsoleSink(format Format) *ConsoleSink {
+	isTTY := isatty.IsTerminal(os.Stderr.Fd()) || isatty.IsCygwinTerminal(os.Stderr.Fd())
+
+	return &ConsoleSink{
+		format: format,
+		out:    os.Stderr,
+		isTTY:  isTTY,
+		colorFn: map[LogLevel]func(format string, a ...interface{}) string{
+			ERROR: color.New(color.FgRed).SprintfFunc(),
+			WARN:  color.New(color.FgYellow).SprintfFunc(),
+			INFO:  color.New(color.FgGreen).SprintfFunc(),
+			DEBUG: color.New(color.FgCyan).SprintfFunc(),
+		},
+	}
+}
+
+// Write renders entry and prints it to stderr, colorizing the level tag
+// when connected to a terminal.
+func (s *ConsoleSink) Write(entry Entry) error {
+	var line string
+	if s.format == FormatJSON {
+		rendered, err := renderJSON(entry)
+		if err != nil {
+			return err
+		}
+		line = string(rendered)
+	} else {
+		levelText := entry.Level.String()
+		if s.isTTY {
+			levelText = s.colorFn[entry.Level]("%s", levelText)
+		}
+		line = renderTextWithLevel(entry, levelText)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := fmt.Fprintln(s.out, line)
+	return err
+}
+
+// Close is a no-op: ConsoleSink doesn't own os.Stderr.
+func (s *ConsoleSink) Close() error {
+	return nil
+}
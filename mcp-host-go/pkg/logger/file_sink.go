@@ -0,0 +1,244 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileSinkConfig configures a FileSink.
+type FileSinkConfig struct {
+	Dir        string
+	FileName   string
+	Format     Format
+	ModuleName string
+
+	// MaxSizeMB rotates the active file once it exceeds this size. Zero
+	// disables size-based rotation.
+	MaxSizeMB int
+	// MaxBackups caps how many rotated files are kept; the oldest beyond
+	// this count are deleted. Zero keeps them all.
+	MaxBackups int
+	// MaxAgeDays deletes rotated files older than this many days. Zero
+	// disables age-based pruning.
+	MaxAgeDays int
+}
+
+// FileSink writes log lines to a file, rotating it by size and pruning old
+// rotated copies by count and age. A rotated file is renamed to
+// "<name>.<unix-timestamp>" and gzipped in the background; the active file
+// keeps its original name so tailing it works across rotations.
+type FileSink struct {
+	config FileSinkConfig
+	path   string
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewFileSink opens (creating if necessary) the configured log file.
+func NewFileSink(config FileSinkConfig) (*FileSink, error) {
+	if config.FileName == "" {
+		config.FileName = "mcp-host.log"
+	}
+
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	path := filepath.Join(config.Dir, config.FileName)
+	file, info, err := openForAppend(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &FileSink{
+		config: config,
+		path:   path,
+		file:   file,
+		size:   info.Size(),
+	}
+
+	startMsg := fmt.Sprintf("\n[%s] === MCP Host Logging Started (Module: %s) ===\n",
+		time.Now().Format(time.RFC3339), config.ModuleName)
+	if n, err := file.WriteString(startMsg); err == nil {
+		sink.size += int64(n)
+	}
+
+	return sink, nil
+}
+
+func openForAppend(path string) (*os.File, os.FileInfo, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to stat log file: %w", err)
+	}
+	return file, info, nil
+}
+
+// Write renders entry and appends it to the active file, rotating first if
+// the file has grown past MaxSizeMB.
+func (s *FileSink) Write(entry Entry) error {
+	line, err := render(s.config.Format, entry)
+	if err != nil {
+		return err
+	}
+	line += "\n"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config.MaxSizeMB > 0 && s.size+int64(len(line)) > int64(s.config.MaxSizeMB)*1024*1024 {
+		if err := s.rotateLocked(); err != nil {
+			fmt.Fprintf(os.Stderr, "logger: rotation failed: %v\n", err)
+		}
+	}
+
+	n, err := s.file.WriteString(line)
+	s.size += int64(n)
+	return err
+}
+
+// rotateLocked renames the active file aside, reopens a fresh one in its
+// place, gzips the rotated file in the background, and prunes old backups.
+// Callers must hold s.mu.
+func (s *FileSink) rotateLocked() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file before rotation: %w", err)
+	}
+
+	rotatedPath := fmt.Sprintf("%s.%d", s.path, time.Now().Unix())
+	if err := os.Rename(s.path, rotatedPath); err != nil {
+		return fmt.Errorf("failed to rename log file for rotation: %w", err)
+	}
+
+	file, info, err := openForAppend(s.path)
+	if err != nil {
+		return err
+	}
+	s.file = file
+	s.size = info.Size()
+
+	go s.compressAndPrune(rotatedPath)
+	return nil
+}
+
+// compressAndPrune gzips a freshly rotated file and then enforces
+// MaxBackups/MaxAgeDays against the rotated-file set. Runs off the logging
+// hot path since neither step needs to block callers of Write.
+func (s *FileSink) compressAndPrune(rotatedPath string) {
+	if gzipped, err := gzipFile(rotatedPath); err == nil {
+		rotatedPath = gzipped
+	} else {
+		fmt.Fprintf(os.Stderr, "logger: failed to gzip rotated log %s: %v\n", rotatedPath, err)
+	}
+
+	if err := s.pruneBackups(); err != nil {
+		fmt.Fprintf(os.Stderr, "logger: failed to prune rotated logs: %v\n", err)
+	}
+}
+
+func gzipFile(path string) (string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	dstPath := path + ".gz"
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return "", err
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		return "", err
+	}
+	if err := gw.Close(); err != nil {
+		return "", err
+	}
+
+	if err := os.Remove(path); err != nil {
+		return "", err
+	}
+	return dstPath, nil
+}
+
+// pruneBackups deletes rotated files beyond MaxBackups (oldest first) and
+// any rotated file older than MaxAgeDays.
+func (s *FileSink) pruneBackups() error {
+	base := filepath.Base(s.path)
+	dir := filepath.Dir(s.path)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	type backup struct {
+		path    string
+		modTime time.Time
+	}
+
+	var backups []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if name == base || !strings.HasPrefix(name, base+".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(dir, name), modTime: info.ModTime()})
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].modTime.Before(backups[j].modTime) })
+
+	if s.config.MaxAgeDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -s.config.MaxAgeDays)
+		kept := backups[:0]
+		for _, b := range backups {
+			if b.modTime.Before(cutoff) {
+				os.Remove(b.path)
+				continue
+			}
+			kept = append(kept, b)
+		}
+		backups = kept
+	}
+
+	if s.config.MaxBackups > 0 && len(backups) > s.config.MaxBackups {
+		for _, b := range backups[:len(backups)-s.config.MaxBackups] {
+			os.Remove(b.path)
+		}
+	}
+
+	return nil
+}
+
+// Close writes a shutdown marker and closes the active file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endMsg := fmt.Sprintf("\n[%s] === MCP Host Logging Ended (Module: %s) ===\n",
+		time.Now().Format(time.RFC3339), s.config.ModuleName)
+	_, _ = s.file.WriteString(endMsg)
+	return s.file.Close()
+}
@@ -0,0 +1,163 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// HTTPSinkConfig configures an HTTPSink.
+type HTTPSinkConfig struct {
+	URL string
+
+	// BatchSize flushes once this many entries have queued. Zero uses a
+	// default of 50.
+	BatchSize int
+	// FlushInterval flushes whatever is queued on a timer even if BatchSize
+	// hasn't been reached. Zero uses a default of 5s.
+	FlushInterval time.Duration
+	// MaxRetries is how many times a failed POST is retried before the
+	// batch is dropped. Zero uses a default of 3.
+	MaxRetries int
+
+	Client *http.Client
+}
+
+// HTTPSink batches JSON-rendered entries and POSTs them to a collector URL,
+// retrying transient failures with a short backoff. Entries are dropped
+// (with a stderr warning) rather than blocking the caller if the collector
+// is unreachable after MaxRetries.
+type HTTPSink struct {
+	config HTTPSinkConfig
+	client *http.Client
+
+	mu      sync.Mutex
+	batch   []json.RawMessage
+	flushCh chan struct{}
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+// NewHTTPSink creates an HTTPSink and starts its background flush loop.
+func NewHTTPSink(config HTTPSinkConfig) *HTTPSink {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 5 * time.Second
+	}
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = 3
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	s := &HTTPSink{
+		config:  config,
+		client:  config.Client,
+		flushCh: make(chan struct{}, 1),
+		closeCh: make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+	go s.flushLoop()
+	return s
+}
+
+// Write renders entry and queues it, waking the background flush loop if the
+// batch is now full. The actual POST (and its retry backoff) always runs on
+// the flush loop's goroutine, never on the caller's, so a slow or
+// unreachable collector can't add latency to whatever request logged entry.
+func (s *HTTPSink) Write(entry Entry) error {
+	line, err := renderJSON(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.batch = append(s.batch, json.RawMessage(line))
+	shouldFlush := len(s.batch) >= s.config.BatchSize
+	s.mu.Unlock()
+
+	if shouldFlush {
+		s.requestFlush()
+	}
+	return nil
+}
+
+// requestFlush wakes the flush loop without blocking the caller. The
+// channel is buffered by one, so a flush already pending absorbs further
+// requests until the loop gets to it.
+func (s *HTTPSink) requestFlush() {
+	select {
+	case s.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *HTTPSink) flushLoop() {
+	defer close(s.doneCh)
+
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush()
+		case <-s.flushCh:
+			s.flush()
+		case <-s.closeCh:
+			s.flush()
+			return
+		}
+	}
+}
+
+func (s *HTTPSink) flush() {
+	s.mu.Lock()
+	if len(s.batch) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	batch := s.batch
+	s.batch = nil
+	s.mu.Unlock()
+
+	body, err := json.Marshal(batch)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "logger: failed to marshal HTTP sink batch: %v\n", err)
+		return
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= s.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * 500 * time.Millisecond)
+		}
+
+		resp, err := s.client.Post(s.config.URL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return
+		}
+		lastErr = fmt.Errorf("unexpected HTTP status %d", resp.StatusCode)
+	}
+
+	fmt.Fprintf(os.Stderr, "logger: dropping %d log entries after failed POST to %s: %v\n", len(batch), s.config.URL, lastErr)
+}
+
+// Close flushes any queued entries and stops the background flush loop.
+func (s *HTTPSink) Close() error {
+	close(s.closeCh)
+	<-s.doneCh
+	return nil
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestHTTPSink_WriteDoesNotBlockOnSlowCollector verifies that filling a
+// batch wakes the background flush loop rather than POSTing on the
+// caller's own goroutine, so Write returns promptly even while the
+// collector is stuck mid-request.
+func TestHTTPSink_WriteDoesNotBlockOnSlowCollector(t *testing.T) {
+	release := make(chan struct{})
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := NewHTTPSink(HTTPSinkConfig{
+		URL:           server.URL,
+		BatchSize:     1,
+		FlushInterval: time.Hour,
+		Client:        &http.Client{Timeout: time.Hour},
+	})
+	defer func() {
+		close(release)
+		sink.Close()
+	}()
+
+	entry := Entry{Message: "hello"}
+
+	done := make(chan error, 1)
+	go func() { done <- sink.Write(entry) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Write blocked on the collector instead of returning")
+	}
+
+	deadline := time.After(time.Second)
+	for atomic.LoadInt32(&requests) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("flush loop never reached the collector")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// TestHTTPSink_CloseFlushesRemainingEntries verifies Close drains whatever
+// is still queued (below BatchSize) before returning.
+func TestHTTPSink_CloseFlushesRemainingEntries(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := NewHTTPSink(HTTPSinkConfig{
+		URL:           server.URL,
+		BatchSize:     50,
+		FlushInterval: time.Hour,
+	})
+
+	if err := sink.Write(Entry{Message: "hello"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Fatalf("requests = %d, want 1", got)
+	}
+}
@@ -1,13 +1,21 @@
+// Package logger provides the structured logger used throughout mcp-host:
+// a small Logger interface backed by a configurable set of Sinks (file,
+// console, HTTP), each of which renders the same Entry independently so one
+// log call can be mirrored to disk, a terminal, and a remote collector at
+// once.
 package logger
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // LogLevel defines the severity levels for logging
@@ -40,61 +48,166 @@ func (l LogLevel) String() string {
 	}
 }
 
-// Logger defines the interface for logging operations
+// Field is a structured key/value pair attached to a log entry.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F creates a Field. It's the plain-value counterpart to zap.String,
+// zap.Int, etc. for call sites that don't already depend on zap.
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Entry is the fully-resolved record handed to every Sink.
+type Entry struct {
+	Time    time.Time
+	Level   LogLevel
+	Module  string
+	Message string
+	Fields  []Field
+}
+
+// Logger defines the interface for logging operations. Error/Warn/Info/Debug
+// accept either logger.Field values, zap.Field values (so existing call
+// sites that already pass zap.String/zap.Any/... keep working unchanged),
+// or plain values that are recorded positionally.
 type Logger interface {
 	Error(message string, args ...interface{})
 	Warn(message string, args ...interface{})
 	Info(message string, args ...interface{})
 	Debug(message string, args ...interface{})
-}
 
-// FileLogger implements the Logger interface with file output
-type FileLogger struct {
-	moduleName string
-	logLevel   LogLevel
-	writer     io.Writer
-	mutex      sync.Mutex
+	// With returns a Logger that prepends fields to every entry it logs.
+	With(fields ...Field) Logger
 }
 
-// Config holds configuration parameters for the logger
+// Config holds configuration parameters for the logger.
 type Config struct {
 	LogLevel   LogLevel
-	LogDir     string
-	LogFile    string
 	ModuleName string
+
+	// Format selects the rendering used by sinks that render text, i.e.
+	// FileSink and ConsoleSink (HTTPSink always sends JSON).
+	Format Format
+
+	// Sinks are wired up by NewLogger in order; Close tears them down in
+	// the same order they were opened.
+	Sinks []Sink
+}
+
+// MultiSinkLogger is the default Logger implementation: it fans every entry
+// out to a fixed list of Sinks.
+type MultiSinkLogger struct {
+	moduleName string
+	logLevel   LogLevel
+	sinks      []Sink
+	fields     []Field
 }
 
-// NewFileLogger creates a new file logger with the given configuration
-func NewFileLogger(config Config) (*FileLogger, error) {
-	// Ensure log directory exists
-	if err := os.MkdirAll(config.LogDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create log directory: %w", err)
+// NewMultiSinkLogger creates a Logger that writes to the given sinks.
+func NewMultiSinkLogger(config Config) *MultiSinkLogger {
+	return &MultiSinkLogger{
+		moduleName: config.ModuleName,
+		logLevel:   config.LogLevel,
+		sinks:      config.Sinks,
 	}
+}
 
-	// Open log file
-	logPath := filepath.Join(config.LogDir, config.LogFile)
-	file, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open log file: %w", err)
+func (l *MultiSinkLogger) With(fields ...Field) Logger {
+	combined := make([]Field, 0, len(l.fields)+len(fields))
+	combined = append(combined, l.fields...)
+	combined = append(combined, fields...)
+	return &MultiSinkLogger{
+		moduleName: l.moduleName,
+		logLevel:   l.logLevel,
+		sinks:      l.sinks,
+		fields:     combined,
 	}
+}
 
-	// Write header
-	startMsg := fmt.Sprintf("\n[%s] === MCP Host Logging Started (Module: %s) ===\n",
-		time.Now().Format(time.RFC3339), config.ModuleName)
-	if _, err := file.WriteString(startMsg); err != nil {
-		return nil, fmt.Errorf("failed to write log header: %w", err)
+func (l *MultiSinkLogger) log(level LogLevel, message string, args ...interface{}) {
+	if l.logLevel < level {
+		return
 	}
 
-	return &FileLogger{
-		moduleName: config.ModuleName,
-		logLevel:   config.LogLevel,
-		writer:     file,
-	}, nil
+	fields := make([]Field, 0, len(l.fields)+len(args))
+	fields = append(fields, l.fields...)
+	fields = append(fields, argsToFields(args)...)
+
+	entry := Entry{
+		Time:    time.Now(),
+		Level:   level,
+		Module:  l.moduleName,
+		Message: message,
+		Fields:  fields,
+	}
+
+	for _, sink := range l.sinks {
+		if err := sink.Write(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "logger: sink write failed: %v\n", err)
+		}
+	}
+}
+
+func (l *MultiSinkLogger) Error(message string, args ...interface{}) { l.log(ERROR, message, args...) }
+func (l *MultiSinkLogger) Warn(message string, args ...interface{})  { l.log(WARN, message, args...) }
+func (l *MultiSinkLogger) Info(message string, args ...interface{})  { l.log(INFO, message, args...) }
+func (l *MultiSinkLogger) Debug(message string, args ...interface{}) { l.log(DEBUG, message, args...) }
+
+// Close closes every sink, returning the first error encountered (after
+// attempting to close the rest).
+func (l *MultiSinkLogger) Close() error {
+	var firstErr error
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
 
-// DefaultConfig returns a default logger configuration
+// argsToFields turns the variadic args passed to Error/Warn/Info/Debug into
+// Fields: logger.Field and zap.Field values are converted directly (zap.Field
+// via zapcore.MapObjectEncoder, so call sites written against zap keep their
+// structure instead of being flattened to a JSON blob), anything else is
+// recorded under a positional "argN" key.
+func argsToFields(args []interface{}) []Field {
+	if len(args) == 0 {
+		return nil
+	}
+
+	fields := make([]Field, 0, len(args))
+	positional := 0
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case Field:
+			fields = append(fields, v)
+		case zap.Field:
+			enc := zapcore.NewMapObjectEncoder()
+			v.AddTo(enc)
+			fields = append(fields, Field{Key: v.Key, Value: enc.Fields[v.Key]})
+		default:
+			positional++
+			fields = append(fields, Field{Key: "arg" + strconv.Itoa(positional), Value: v})
+		}
+	}
+	return fields
+}
+
+// DefaultConfig returns a logger configuration built from the environment:
+//
+//	LOG_LEVEL          ERROR|WARN|INFO|DEBUG (default DEBUG)
+//	LOG_FORMAT         text|json (default text)
+//	LOG_SINKS          comma-separated list of file,console,http (default file,console)
+//	LOG_DIR            directory for FileSink (default $TMPDIR/mcp-host)
+//	LOG_FILE           file name for FileSink (default mcp-host.log)
+//	LOG_MAX_SIZE_MB    FileSink rotation threshold in megabytes (default 100)
+//	LOG_MAX_BACKUPS    FileSink rotated-file retention count (default 5)
+//	LOG_MAX_AGE_DAYS   FileSink rotated-file retention age in days (default 28)
+//	LOG_HTTP_URL       collector URL for HTTPSink (HTTPSink is only added when set)
 func DefaultConfig(moduleName string) Config {
-	// Get log level from environment variable
 	logLevel := DEBUG
 	switch os.Getenv("LOG_LEVEL") {
 	case "ERROR":
@@ -107,112 +220,86 @@ func DefaultConfig(moduleName string) Config {
 		logLevel = DEBUG
 	}
 
-	// Get log directory
+	format := FormatText
+	if strings.EqualFold(os.Getenv("LOG_FORMAT"), "json") {
+		format = FormatJSON
+	}
+
 	logDir := os.Getenv("LOG_DIR")
 	if logDir == "" {
 		logDir = filepath.Join(os.TempDir(), "mcp-host")
 	}
 
-	// Get log file name
 	logFile := os.Getenv("LOG_FILE")
 	if logFile == "" {
 		logFile = "mcp-host.log"
 	}
 
-	return Config{
-		ModuleName: moduleName,
-		LogLevel:   logLevel,
-		LogDir:     logDir,
-		LogFile:    logFile,
+	sinkNames := os.Getenv("LOG_SINKS")
+	if sinkNames == "" {
+		sinkNames = "file,console"
 	}
-}
 
-// formatMessage formats a log message with timestamp, level, module name and args
-func (l *FileLogger) formatMessage(level LogLevel, message string, args ...interface{}) string {
-	timestamp := time.Now().Format(time.RFC3339)
-	prefix := fmt.Sprintf("[%s] [%s] [%s]", timestamp, level.String(), l.moduleName)
-
-	formattedMessage := fmt.Sprintf("%s %s", prefix, message)
-
-	// Format additional arguments
-	if len(args) > 0 {
-		formattedArgs := make([]string, len(args))
-		for i, arg := range args {
-			if obj, ok := arg.(interface{}); ok {
-				if jsonBytes, err := json.Marshal(obj); err == nil {
-					formattedArgs[i] = string(jsonBytes)
-				} else {
-					formattedArgs[i] = fmt.Sprintf("%+v", arg)
-				}
+	var sinks []Sink
+	for _, name := range strings.Split(sinkNames, ",") {
+		switch strings.TrimSpace(name) {
+		case "file":
+			sink, err := NewFileSink(FileSinkConfig{
+				Dir:        logDir,
+				FileName:   logFile,
+				Format:     format,
+				MaxSizeMB:  envInt("LOG_MAX_SIZE_MB", 100),
+				MaxBackups: envInt("LOG_MAX_BACKUPS", 5),
+				MaxAgeDays: envInt("LOG_MAX_AGE_DAYS", 28),
+				ModuleName: moduleName,
+			})
+			if err == nil {
+				sinks = append(sinks, sink)
 			} else {
-				formattedArgs[i] = fmt.Sprintf("%+v", arg)
+				fmt.Fprintf(os.Stderr, "logger: failed to open file sink: %v\n", err)
+			}
+		case "console":
+			sinks = append(sinks, NewConsoleSink(format))
+		case "http":
+			if url := os.Getenv("LOG_HTTP_URL"); url != "" {
+				sinks = append(sinks, NewHTTPSink(HTTPSinkConfig{URL: url}))
 			}
 		}
-		for _, arg := range formattedArgs {
-			formattedMessage += " " + arg
-		}
-	}
-
-	return formattedMessage
-}
-
-// writeToFile writes a formatted log message to the file
-func (l *FileLogger) writeToFile(formattedMessage string) {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-
-	if l.writer != nil {
-		_, _ = fmt.Fprintln(l.writer, formattedMessage)
 	}
-}
 
-// Error logs an error message
-func (l *FileLogger) Error(message string, args ...interface{}) {
-	if l.logLevel >= ERROR {
-		formattedMessage := l.formatMessage(ERROR, message, args...)
-		l.writeToFile(formattedMessage)
+	return Config{
+		ModuleName: moduleName,
+		LogLevel:   logLevel,
+		Format:     format,
+		Sinks:      sinks,
 	}
 }
 
-// Warn logs a warning message
-func (l *FileLogger) Warn(message string, args ...interface{}) {
-	if l.logLevel >= WARN {
-		formattedMessage := l.formatMessage(WARN, message, args...)
-		l.writeToFile(formattedMessage)
+func envInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
 	}
-}
-
-// Info logs an informational message
-func (l *FileLogger) Info(message string, args ...interface{}) {
-	if l.logLevel >= INFO {
-		formattedMessage := l.formatMessage(INFO, message, args...)
-		l.writeToFile(formattedMessage)
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fallback
 	}
+	return parsed
 }
 
-// Debug logs a debug message
-func (l *FileLogger) Debug(message string, args ...interface{}) {
-	if l.logLevel >= DEBUG {
-		formattedMessage := l.formatMessage(DEBUG, message, args...)
-		l.writeToFile(formattedMessage)
-	}
-}
+// loggerMu guards construction of the shared sink set so concurrent
+// NewLogger calls for different modules don't race opening the same file.
+var loggerMu sync.Mutex
 
-// Close closes the log file
-func (l *FileLogger) Close() error {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
+// NewLogger is a convenience function that creates a logger with default
+// config, sourced from the environment via DefaultConfig.
+func NewLogger(moduleName string) (Logger, error) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
 
-	if closer, ok := l.writer.(io.Closer); ok {
-		endMsg := fmt.Sprintf("\n[%s] === MCP Host Logging Ended (Module: %s) ===\n",
-			time.Now().Format(time.RFC3339), l.moduleName)
-		_, _ = fmt.Fprintln(l.writer, endMsg)
-		return closer.Close()
+	config := DefaultConfig(moduleName)
+	if len(config.Sinks) == 0 {
+		config.Sinks = []Sink{NewConsoleSink(config.Format)}
 	}
-	return nil
-}
-
-// NewLogger is a convenience function that creates a logger with default config
-func NewLogger(moduleName string) (Logger, error) {
-	return NewFileLogger(DefaultConfig(moduleName))
+	return NewMultiSinkLogger(config), nil
 }
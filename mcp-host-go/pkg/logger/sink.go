@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Sink receives every log Entry that passes the Logger's level filter and
+// renders or forwards it somewhere: a rotating file, the console, a remote
+// collector, and so on. A Logger can write to several Sinks at once.
+type Sink interface {
+	Write(entry Entry) error
+	Close() error
+}
+
+// Format selects how a text-capable Sink renders an Entry.
+type Format string
+
+const (
+	// FormatText renders "timestamp [LEVEL] [module] message key=value ...".
+	FormatText Format = "text"
+	// FormatJSON renders one JSON object per line.
+	FormatJSON Format = "json"
+)
+
+// renderText formats an Entry as a single human-readable line.
+func renderText(entry Entry) string {
+	return renderTextWithLevel(entry, entry.Level.String())
+}
+
+// renderTextWithLevel is renderText with the level tag substituted for
+// levelText, so ConsoleSink can splice in an ANSI-colorized version without
+// duplicating the rest of the line layout.
+func renderTextWithLevel(entry Entry, levelText string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] [%s] [%s] %s",
+		entry.Time.Format("2006-01-02T15:04:05.000Z07:00"), levelText, entry.Module, entry.Message)
+	for _, field := range entry.Fields {
+		fmt.Fprintf(&b, " %s=%v", field.Key, field.Value)
+	}
+	return b.String()
+}
+
+// renderJSON formats an Entry as a single JSON object line.
+func renderJSON(entry Entry) ([]byte, error) {
+	obj := make(map[string]interface{}, len(entry.Fields)+4)
+	obj["time"] = entry.Time.Format("2006-01-02T15:04:05.000Z07:00")
+	obj["level"] = entry.Level.String()
+	obj["module"] = entry.Module
+	obj["message"] = entry.Message
+	for _, field := range entry.Fields {
+		obj[field.Key] = field.Value
+	}
+	return json.Marshal(obj)
+}
+
+// render dispatches to renderText/renderJSON based on format, always
+// returning a line with no trailing newline.
+func render(format Format, entry Entry) (string, error) {
+	if format == FormatJSON {
+		line, err := renderJSON(entry)
+		if err != nil {
+			return "", err
+		}
+		return string(line), nil
+	}
+	return renderText(entry), nil
+}
@@ -0,0 +1,379 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	goplugin "github.com/hashicorp/go-plugin"
+	"go.uber.org/zap"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	nbplugin "github.com/algonius/algonius-browser/mcp-host-go/pkg/plugin"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/plugin/proto"
+)
+
+const (
+	pluginRestartInitialBackoff = time.Second
+	pluginRestartMaxBackoff     = 30 * time.Second
+	pluginCrashPollInterval     = 500 * time.Millisecond
+)
+
+// PluginManagerConfig contains configuration for PluginManager
+type PluginManagerConfig struct {
+	Logger logger.Logger
+	Server *Server
+
+	// Dir is scanned for executable plugin binaries by LoadAll; each
+	// discovered binary is launched as its own go-plugin subprocess.
+	Dir string
+}
+
+// pluginInstance tracks everything PluginManager needs to restart or tear
+// down a single plugin binary: the live client and go-plugin.Client, and the
+// names/URIs it registered so they can be unregistered before a reload.
+type pluginInstance struct {
+	path         string
+	client       *goplugin.Client
+	toolNames    []string
+	resourceURIs []string
+
+	stopMonitor chan struct{}
+}
+
+// PluginManager discovers out-of-process plugin binaries and bridges each
+// one's tools and resources into Server via the same RegisterTool /
+// RegisterResource calls an in-process tool would use. Each plugin runs in
+// its own OS process: a crash is detected by polling goplugin.Client.Exited
+// and the plugin is relaunched with exponential backoff, and Watch keeps an
+// fsnotify watch on Dir so a rebuilt or newly dropped binary is picked up
+// without restarting the host.
+type PluginManager struct {
+	logger logger.Logger
+	server *Server
+	dir    string
+
+	mu           sync.Mutex
+	instances    map[string]*pluginInstance
+	shuttingDown bool
+
+	watcher *fsnotify.Watcher
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewPluginManager creates a new PluginManager
+func NewPluginManager(config PluginManagerConfig) (*PluginManager, error) {
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if config.Server == nil {
+		return nil, fmt.Errorf("server is required")
+	}
+
+	return &PluginManager{
+		logger:    config.Logger,
+		server:    config.Server,
+		dir:       config.Dir,
+		instances: make(map[string]*pluginInstance),
+		stopCh:    make(chan struct{}),
+	}, nil
+}
+
+// LoadAll discovers every executable file directly inside Dir and launches
+// each as a plugin subprocess, registering its tools and resources onto
+// Server. A missing Dir is not an error: plugins are an optional extension
+// point.
+func (m *PluginManager) LoadAll() error {
+	if m.dir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Mode()&0111 == 0 {
+			continue
+		}
+
+		path := filepath.Join(m.dir, entry.Name())
+		if err := m.load(path); err != nil {
+			m.logger.Error("Failed to load plugin, skipping", zap.String("path", path), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// load launches one plugin binary, registers its tools and resources, and
+// starts a background goroutine that watches for the subprocess crashing.
+func (m *PluginManager) load(path string) error {
+	instance, err := m.start(path)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.instances[path] = instance
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go m.monitor(instance)
+
+	return nil
+}
+
+// start launches the plugin binary at path, registers its tools and
+// resources, and returns the resulting instance without touching
+// m.instances (so restart can swap it in atomically).
+func (m *PluginManager) start(path string) (*pluginInstance, error) {
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig: nbplugin.Handshake,
+		Plugins: map[string]goplugin.Plugin{
+			nbplugin.PluginName: &nbplugin.GRPCPlugin{},
+		},
+		Cmd:              exec.Command(path),
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+	})
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, fmt.Errorf("failed to start plugin: %w", err)
+	}
+
+	raw, err := rpcClient.Dispense(nbplugin.PluginName)
+	if err != nil {
+		client.Kill()
+		return nil, fmt.Errorf("failed to dispense plugin: %w", err)
+	}
+
+	pluginClient, ok := raw.(proto.PluginV1Client)
+	if !ok {
+		client.Kill()
+		return nil, fmt.Errorf("plugin did not dispense a PluginV1Client")
+	}
+
+	ctx := context.Background()
+	instance := &pluginInstance{path: path, client: client, stopMonitor: make(chan struct{})}
+
+	toolsResp, err := pluginClient.ListTools(ctx, &proto.ListToolsRequest{})
+	if err != nil {
+		client.Kill()
+		return nil, fmt.Errorf("failed to list plugin tools: %w", err)
+	}
+	for _, tool := range toolsResp.Tools {
+		if err := m.server.RegisterTool(newPluginTool(tool, pluginClient, m.logger)); err != nil {
+			m.logger.Error("Failed to register plugin tool", zap.String("path", path), zap.String("tool", tool.Name), zap.Error(err))
+			continue
+		}
+		instance.toolNames = append(instance.toolNames, tool.Name)
+	}
+
+	var resourceCount int
+	resourcesResp, err := pluginClient.ListResources(ctx, &proto.ListResourcesRequest{})
+	if err != nil {
+		m.logger.Warn("Failed to list plugin resources", zap.String("path", path), zap.Error(err))
+	} else {
+		resourceCount = len(resourcesResp.Resources)
+		for _, resource := range resourcesResp.Resources {
+			if err := m.server.RegisterResource(newPluginResource(resource, pluginClient, m.logger)); err != nil {
+				m.logger.Error("Failed to register plugin resource", zap.String("path", path), zap.String("uri", resource.URI), zap.Error(err))
+				continue
+			}
+			instance.resourceURIs = append(instance.resourceURIs, resource.URI)
+		}
+	}
+
+	m.logger.Info("Loaded plugin",
+		zap.String("path", path),
+		zap.Int("tools", len(instance.toolNames)),
+		zap.Int("resources", resourceCount))
+
+	return instance, nil
+}
+
+// unregister removes every tool and resource an instance registered, e.g.
+// before restarting or reloading it.
+func (m *PluginManager) unregister(instance *pluginInstance) {
+	for _, name := range instance.toolNames {
+		m.server.UnregisterTool(name)
+	}
+	for _, uri := range instance.resourceURIs {
+		m.server.UnregisterResource(uri)
+	}
+}
+
+// monitor polls instance.client.Exited and restarts the plugin with
+// exponential backoff once it crashes, until Shutdown or a reload replaces
+// it with a fresh instance (signaled via instance.stopMonitor).
+func (m *PluginManager) monitor(instance *pluginInstance) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(pluginCrashPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case <-instance.stopMonitor:
+			return
+		case <-ticker.C:
+			if !instance.client.Exited() {
+				continue
+			}
+
+			m.logger.Error("Plugin crashed, unregistering and restarting", zap.String("path", instance.path))
+			m.unregister(instance)
+
+			backoff := newRestartBackoff()
+			for {
+				delay := backoff.next()
+				select {
+				case <-m.stopCh:
+					return
+				case <-time.After(delay):
+				}
+
+				restarted, err := m.start(instance.path)
+				if err == nil {
+					m.mu.Lock()
+					if m.shuttingDown {
+						// Shutdown ran (and swept m.instances) while this
+						// restart was in flight. Inserting restarted now
+						// would orphan it: Shutdown already killed the
+						// instances it saw and is past the point of looking
+						// again. Kill the freshly spawned process
+						// immediately instead of registering it.
+						m.mu.Unlock()
+						restarted.client.Kill()
+						return
+					}
+					m.instances[instance.path] = restarted
+					m.mu.Unlock()
+					m.wg.Add(1)
+					go m.monitor(restarted)
+					return
+				}
+
+				m.logger.Error("Failed to restart crashed plugin, backing off",
+					zap.String("path", instance.path), zap.Duration("backoff", delay), zap.Error(err))
+			}
+		}
+	}
+}
+
+// Watch starts an fsnotify watch on Dir so a plugin binary that's rebuilt or
+// newly dropped in is (re)loaded without restarting the host. Watch is a
+// no-op if Dir is empty; failures to establish the watch are logged rather
+// than returned, since hot reload is a convenience on top of LoadAll.
+func (m *PluginManager) Watch() {
+	if m.dir == "" {
+		return
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		m.logger.Error("Failed to start plugin directory watcher", zap.Error(err))
+		return
+	}
+	if err := watcher.Add(m.dir); err != nil {
+		m.logger.Error("Failed to watch plugin directory", zap.String("dir", m.dir), zap.Error(err))
+		watcher.Close()
+		return
+	}
+
+	m.watcher = watcher
+	m.wg.Add(1)
+	go m.watchLoop(watcher)
+}
+
+func (m *PluginManager) watchLoop(watcher *fsnotify.Watcher) {
+	defer m.wg.Done()
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			m.reload(event.Name)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			m.logger.Error("Plugin directory watch error", zap.Error(err))
+		}
+	}
+}
+
+// reload tears down any existing instance for path and starts a fresh one,
+// e.g. because the binary on disk changed.
+func (m *PluginManager) reload(path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	existing := m.instances[path]
+	delete(m.instances, path)
+	m.mu.Unlock()
+
+	if existing != nil {
+		close(existing.stopMonitor)
+		m.unregister(existing)
+		existing.client.Kill()
+	}
+
+	m.logger.Info("Reloading plugin", zap.String("path", path))
+	if err := m.load(path); err != nil {
+		m.logger.Error("Failed to reload plugin", zap.String("path", path), zap.Error(err))
+	}
+}
+
+// Shutdown terminates every loaded plugin subprocess and stops the directory
+// watcher, if one was started. shuttingDown is set under the same lock
+// monitor's restart path checks before registering a restarted instance, so
+// a restart racing this call is killed immediately instead of being
+// inserted into a map Shutdown has already swept and orphaned.
+func (m *PluginManager) Shutdown() {
+	close(m.stopCh)
+
+	if m.watcher != nil {
+		m.watcher.Close()
+	}
+
+	m.mu.Lock()
+	m.shuttingDown = true
+	instances := m.instances
+	m.instances = make(map[string]*pluginInstance)
+	m.mu.Unlock()
+
+	for _, instance := range instances {
+		instance.client.Kill()
+	}
+
+	m.wg.Wait()
+}
@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/plugin/proto"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// pluginResource adapts one plugin-provided resource into types.Resource so
+// it can be registered onto Server exactly like an in-process resource.
+type pluginResource struct {
+	resource proto.Resource
+	client   proto.PluginV1Client
+	logger   logger.Logger
+}
+
+func newPluginResource(resource proto.Resource, client proto.PluginV1Client, log logger.Logger) *pluginResource {
+	return &pluginResource{resource: resource, client: client, logger: log}
+}
+
+// GetURI returns the resource URI
+func (r *pluginResource) GetURI() string {
+	return r.resource.URI
+}
+
+// GetName returns the resource name
+func (r *pluginResource) GetName() string {
+	return r.resource.Name
+}
+
+// GetMimeType returns the resource MIME type
+func (r *pluginResource) GetMimeType() string {
+	return r.resource.MimeType
+}
+
+// GetDescription returns the resource description
+func (r *pluginResource) GetDescription() string {
+	return r.resource.Description
+}
+
+// Read reads the resource with no arguments
+func (r *pluginResource) Read() (types.ResourceContent, error) {
+	return r.ReadWithArguments(r.resource.URI, nil)
+}
+
+// ReadWithArguments calls ReadResource on the owning plugin subprocess over
+// gRPC, the same crash-isolation tradeoff as pluginTool.Execute.
+func (r *pluginResource) ReadWithArguments(uri string, arguments map[string]any) (types.ResourceContent, error) {
+	resp, err := r.client.ReadResource(context.Background(), &proto.ReadResourceRequest{URI: uri, Arguments: arguments})
+	if err != nil {
+		r.logger.Error("Plugin resource read failed", zap.String("uri", uri), zap.Error(err))
+		return types.ResourceContent{}, fmt.Errorf("plugin resource %s failed: %w", uri, err)
+	}
+	if resp.Error != "" {
+		return types.ResourceContent{}, fmt.Errorf("%s", resp.Error)
+	}
+
+	contents := make([]types.ResourceItem, 0, len(resp.Contents))
+	for _, item := range resp.Contents {
+		contents = append(contents, types.ResourceItem{URI: item.URI, MimeType: item.MimeType, Text: item.Text})
+	}
+	return types.ResourceContent{Contents: contents}, nil
+}
@@ -0,0 +1,28 @@
+package mcp
+
+import "time"
+
+// restartBackoff tracks the exponential delay between restart attempts for
+// a crashed plugin subprocess, shared by PluginManager's and
+// StdioPluginManager's monitor loops so both transports retry on identical
+// timing instead of keeping their own copies of the doubling logic.
+type restartBackoff struct {
+	delay time.Duration
+}
+
+// newRestartBackoff starts a backoff sequence at pluginRestartInitialBackoff.
+func newRestartBackoff() *restartBackoff {
+	return &restartBackoff{delay: pluginRestartInitialBackoff}
+}
+
+// next returns how long to wait before the next restart attempt, then
+// advances the delay towards pluginRestartMaxBackoff for the attempt after
+// that.
+func (b *restartBackoff) next() time.Duration {
+	d := b.delay
+	b.delay *= 2
+	if b.delay > pluginRestartMaxBackoff {
+		b.delay = pluginRestartMaxBackoff
+	}
+	return d
+}
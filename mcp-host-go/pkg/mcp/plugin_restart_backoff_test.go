@@ -0,0 +1,31 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRestartBackoff_DoublesUntilCeiling(t *testing.T) {
+	b := newRestartBackoff()
+
+	got := []time.Duration{b.next(), b.next(), b.next(), b.next(), b.next(), b.next()}
+	want := []time.Duration{
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		16 * time.Second,
+		30 * time.Second, // would be 32s, clamped to the 30s ceiling
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("next()[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	// Once at the ceiling, further calls stay there.
+	if got := b.next(); got != pluginRestartMaxBackoff {
+		t.Fatalf("expected backoff to stay at the ceiling, got %s", got)
+	}
+}
@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/plugin/proto"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// pluginTool adapts one plugin-provided tool into types.Tool so it can be
+// registered onto Server exactly like an in-process tool.
+type pluginTool struct {
+	tool   proto.Tool
+	client proto.PluginV1Client
+	logger logger.Logger
+}
+
+func newPluginTool(tool proto.Tool, client proto.PluginV1Client, log logger.Logger) *pluginTool {
+	return &pluginTool{tool: tool, client: client, logger: log}
+}
+
+// GetName returns the tool name
+func (t *pluginTool) GetName() string {
+	return t.tool.Name
+}
+
+// GetDescription returns the tool description
+func (t *pluginTool) GetDescription() string {
+	return t.tool.Description
+}
+
+// GetInputSchema returns the tool input schema
+func (t *pluginTool) GetInputSchema() interface{} {
+	return t.tool.InputSchema
+}
+
+// Execute calls CallTool on the owning plugin subprocess over gRPC. A
+// transport-level error here (e.g. the plugin process crashed) is logged
+// and returned to the caller as a normal tool failure; it doesn't affect the
+// host or any other registered tool.
+func (t *pluginTool) Execute(args map[string]interface{}) (types.ToolResult, error) {
+	resp, err := t.client.CallTool(context.Background(), &proto.CallToolRequest{Name: t.tool.Name, Args: args})
+	if err != nil {
+		t.logger.Error("Plugin tool call failed", zap.String("tool", t.tool.Name), zap.Error(err))
+		return types.ToolResult{}, fmt.Errorf("plugin tool %s failed: %w", t.tool.Name, err)
+	}
+	if resp.Error != "" {
+		return types.ToolResult{}, fmt.Errorf("%s", resp.Error)
+	}
+
+	content := make([]types.ToolResultItem, 0, len(resp.Content))
+	for _, item := range resp.Content {
+		content = append(content, types.ToolResultItem{Type: item.Type, Text: item.Text})
+	}
+	return types.ToolResult{Content: content}, nil
+}
@@ -0,0 +1,259 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// ConsulConfig configures a ConsulRegistry.
+type ConsulConfig struct {
+	// Addr is the Consul agent's HTTP API address, e.g. "http://127.0.0.1:8500".
+	// Defaults to CONSUL_HTTP_ADDR, then "http://127.0.0.1:8500".
+	Addr string
+
+	Client *http.Client
+}
+
+// ConsulRegistry discovers peer hosts via a Consul agent's HTTP API. It
+// talks directly to the agent's catalog/agent endpoints rather than
+// depending on the full hashicorp/consul/api SDK, consistent with how the
+// rest of this host talks to other HTTP services (see logger.HTTPSink).
+type ConsulRegistry struct {
+	addr   string
+	client *http.Client
+}
+
+// NewConsulRegistry creates a ConsulRegistry.
+func NewConsulRegistry(config ConsulConfig) (*ConsulRegistry, error) {
+	if config.Addr == "" {
+		config.Addr = envOr("CONSUL_HTTP_ADDR", "http://127.0.0.1:8500")
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &ConsulRegistry{addr: config.Addr, client: config.Client}, nil
+}
+
+type consulServiceRegistration struct {
+	ID      string            `json:"ID"`
+	Name    string            `json:"Name"`
+	Address string            `json:"Address"`
+	Port    int               `json:"Port"`
+	Meta    map[string]string `json:"Meta,omitempty"`
+	Check   *consulCheck      `json:"Check,omitempty"`
+}
+
+type consulCheck struct {
+	TTL                            string `json:"TTL"`
+	DeregisterCriticalServiceAfter string `json:"DeregisterCriticalServiceAfter"`
+}
+
+type consulCatalogEntry struct {
+	ServiceID      string            `json:"ServiceID"`
+	ServiceAddress string            `json:"ServiceAddress"`
+	ServicePort    int               `json:"ServicePort"`
+	ServiceMeta    map[string]string `json:"ServiceMeta"`
+}
+
+// Register registers addr with Consul under ServiceName and attaches a TTL
+// health check; the caller's heartbeat loop must then keep calling Register
+// (or the check would need a separate pass call) more often than ttl or
+// Consul will mark the instance critical and eventually remove it.
+func (r *ConsulRegistry) Register(info types.HostInfo, addr string, ttl time.Duration) (string, error) {
+	id := fmt.Sprintf("%s-%s", ServiceName, info.ID)
+	host, port, err := splitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+
+	meta, err := hostInfoToMeta(info)
+	if err != nil {
+		return "", err
+	}
+
+	reg := consulServiceRegistration{
+		ID:      id,
+		Name:    ServiceName,
+		Address: host,
+		Port:    port,
+		Meta:    meta,
+		Check: &consulCheck{
+			TTL:                            ttl.String(),
+			DeregisterCriticalServiceAfter: (ttl * 10).String(),
+		},
+	}
+
+	if err := r.put("/v1/agent/service/register", reg); err != nil {
+		return "", fmt.Errorf("failed to register with consul: %w", err)
+	}
+
+	// Registering doesn't itself pass the TTL check; do an immediate pass so
+	// the instance isn't momentarily reported critical.
+	if err := r.put(fmt.Sprintf("/v1/agent/check/pass/service:%s", id), nil); err != nil {
+		return "", fmt.Errorf("failed to pass consul health check: %w", err)
+	}
+
+	return id, nil
+}
+
+// Deregister removes id from Consul.
+func (r *ConsulRegistry) Deregister(id string) error {
+	req, err := http.NewRequest(http.MethodPut, r.addr+"/v1/agent/service/deregister/"+id, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deregister from consul: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("consul deregister returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// List queries Consul's service catalog for every registered instance of
+// serviceName. Health-check status is not consulted, so instances whose TTL
+// check is critical are listed until Consul deregisters them.
+func (r *ConsulRegistry) List(serviceName string) ([]HostEntry, error) {
+	resp, err := r.client.Get(r.addr + "/v1/catalog/service/" + serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query consul catalog: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("consul catalog query returned status %d", resp.StatusCode)
+	}
+
+	var catalogEntries []consulCatalogEntry
+	if err := json.NewDecoder(resp.Body).Decode(&catalogEntries); err != nil {
+		return nil, fmt.Errorf("failed to decode consul catalog response: %w", err)
+	}
+
+	entries := make([]HostEntry, 0, len(catalogEntries))
+	for _, c := range catalogEntries {
+		info, err := metaToHostInfo(c.ServiceMeta)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, HostEntry{
+			ID:       c.ServiceID,
+			Addr:     fmt.Sprintf("%s:%d", c.ServiceAddress, c.ServicePort),
+			HostInfo: info,
+		})
+	}
+
+	return entries, nil
+}
+
+// Watch re-runs List every 5 seconds for serviceName, sending an Event for
+// every host added or removed since the last poll.
+func (r *ConsulRegistry) Watch(serviceName string, ch chan<- Event) {
+	go func() {
+		var previous map[string]HostEntry
+
+		for {
+			entries, err := r.List(serviceName)
+			if err != nil {
+				time.Sleep(5 * time.Second)
+				continue
+			}
+
+			current := make(map[string]HostEntry, len(entries))
+			for _, entry := range entries {
+				current[entry.ID] = entry
+			}
+
+			for id, entry := range current {
+				if _, existed := previous[id]; !existed {
+					ch <- Event{Type: EventAdded, Entry: entry}
+				}
+			}
+			for id, entry := range previous {
+				if _, stillThere := current[id]; !stillThere {
+					ch <- Event{Type: EventRemoved, Entry: entry}
+				}
+			}
+
+			previous = current
+			time.Sleep(5 * time.Second)
+		}
+	}()
+}
+
+func (r *ConsulRegistry) put(path string, body interface{}) error {
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, r.addr+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("consul request to %s returned status %d", path, resp.StatusCode)
+	}
+	return nil
+}
+
+func splitHostPort(addr string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid addr %q: %w", addr, err)
+	}
+	var port int
+	if _, err := fmt.Sscanf(portStr, "%d", &port); err != nil {
+		return "", 0, fmt.Errorf("invalid port in addr %q: %w", addr, err)
+	}
+	return host, port, nil
+}
+
+// hostInfoToMeta/metaToHostInfo round-trip a HostInfo through Consul's flat
+// string-to-string service metadata by JSON-encoding it into a single entry,
+// since HostInfo's shape is owned by pkg/types and shouldn't be duplicated
+// field-by-field here.
+func hostInfoToMeta(info types.HostInfo) (map[string]string, error) {
+	encoded, err := json.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode host info: %w", err)
+	}
+	return map[string]string{"hostInfo": string(encoded)}, nil
+}
+
+func metaToHostInfo(meta map[string]string) (types.HostInfo, error) {
+	var info types.HostInfo
+	encoded, ok := meta["hostInfo"]
+	if !ok {
+		return info, fmt.Errorf("missing hostInfo metadata")
+	}
+	if err := json.Unmarshal([]byte(encoded), &info); err != nil {
+		return info, fmt.Errorf("failed to decode host info: %w", err)
+	}
+	return info, nil
+}
@@ -0,0 +1,225 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/mdns"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// MDNSConfig configures an MDNSRegistry.
+type MDNSConfig struct {
+	// Domain is the mDNS domain entries are published/looked up under.
+	// Defaults to "local" (read from MDNS_DOMAIN if set).
+	Domain string
+	// PollInterval controls how often Watch re-queries the network for
+	// changes, since mDNS has no native push-on-change notification.
+	// Defaults to 10s (read from MDNS_POLL_INTERVAL_SECONDS if set).
+	PollInterval time.Duration
+}
+
+// mdnsRegistration is encoded into the TXT record of a registered instance
+// so peers can recover its HostInfo without a separate RPC round trip.
+type mdnsRegistration struct {
+	ID       string         `json:"id"`
+	HostInfo types.HostInfo `json:"hostInfo"`
+}
+
+// MDNSRegistry discovers peer hosts on the local network via mDNS/DNS-SD.
+// It's meant for local multi-browser setups on a single LAN; team
+// deployments that need to cross subnets should use ConsulRegistry instead.
+type MDNSRegistry struct {
+	domain       string
+	pollInterval time.Duration
+
+	mu       sync.Mutex
+	servers  map[string]*mdns.Server
+	watchers []chan<- Event
+	seen     map[string]map[string]HostEntry // serviceName -> id -> entry
+}
+
+// NewMDNSRegistry creates an MDNSRegistry.
+func NewMDNSRegistry(config MDNSConfig) (*MDNSRegistry, error) {
+	if config.Domain == "" {
+		config.Domain = envOr("MDNS_DOMAIN", "local")
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = envDurationSeconds("MDNS_POLL_INTERVAL_SECONDS", 10*time.Second)
+	}
+
+	return &MDNSRegistry{
+		domain:       config.Domain,
+		pollInterval: config.PollInterval,
+		servers:      make(map[string]*mdns.Server),
+		seen:         make(map[string]map[string]HostEntry),
+	}, nil
+}
+
+// Register advertises info under ServiceName via an mDNS responder bound to
+// addr. ttl is accepted for interface compatibility with other backends;
+// mDNS advertisements have no TTL of their own and simply stop answering
+// once Deregister shuts the responder down.
+func (r *MDNSRegistry) Register(info types.HostInfo, addr string, ttl time.Duration) (string, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid addr %q: %w", addr, err)
+	}
+	var port int
+	if _, err := fmt.Sscanf(portStr, "%d", &port); err != nil {
+		return "", fmt.Errorf("invalid port in addr %q: %w", addr, err)
+	}
+
+	id := fmt.Sprintf("%s-%d", info.ID, port)
+
+	r.mu.Lock()
+	_, registered := r.servers[id]
+	r.mu.Unlock()
+	if registered {
+		// A repeat Register (e.g. a heartbeat refresh) keeps the responder
+		// already advertising this host.
+		return id, nil
+	}
+
+	reg := mdnsRegistration{ID: id, HostInfo: info}
+	txtBytes, err := json.Marshal(reg)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode registration: %w", err)
+	}
+
+	hostname := host
+	if hostname == "" || hostname == "0.0.0.0" {
+		hostname, err = os.Hostname()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve local hostname: %w", err)
+		}
+	}
+
+	service, err := mdns.NewMDNSService(id, ServiceName, r.domain+".", hostname+".", port, nil, []string{string(txtBytes)})
+	if err != nil {
+		return "", fmt.Errorf("failed to build mDNS service record: %w", err)
+	}
+
+	server, err := mdns.NewServer(&mdns.Config{Zone: service})
+	if err != nil {
+		return "", fmt.Errorf("failed to start mDNS responder: %w", err)
+	}
+
+	r.mu.Lock()
+	r.servers[id] = server
+	r.mu.Unlock()
+
+	return id, nil
+}
+
+// Deregister stops answering mDNS queries for id.
+func (r *MDNSRegistry) Deregister(id string) error {
+	r.mu.Lock()
+	server, ok := r.servers[id]
+	delete(r.servers, id)
+	r.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return server.Shutdown()
+}
+
+// List runs a one-shot mDNS lookup for serviceName and returns every host
+// that answered.
+func (r *MDNSRegistry) List(serviceName string) ([]HostEntry, error) {
+	entriesCh := make(chan *mdns.ServiceEntry, 16)
+	done := make(chan struct{})
+	var results []HostEntry
+
+	go func() {
+		defer close(done)
+		for entry := range entriesCh {
+			if host, ok := parseMDNSEntry(entry); ok {
+				results = append(results, host)
+			}
+		}
+	}()
+
+	err := mdns.Lookup(serviceName, entriesCh)
+	close(entriesCh)
+	<-done
+	if err != nil {
+		return nil, fmt.Errorf("mDNS lookup failed: %w", err)
+	}
+
+	return results, nil
+}
+
+// Watch polls List on PollInterval and diffs successive snapshots, sending
+// an Event for every host that newly appears or disappears. It runs until
+// the process exits; there is no Close since MDNSRegistry instances live
+// for the lifetime of the Server that owns them.
+func (r *MDNSRegistry) Watch(serviceName string, ch chan<- Event) {
+	go func() {
+		ticker := time.NewTicker(r.pollInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			entries, err := r.List(serviceName)
+			if err != nil {
+				continue
+			}
+			r.diffAndEmit(serviceName, entries, ch)
+		}
+	}()
+}
+
+func (r *MDNSRegistry) diffAndEmit(serviceName string, entries []HostEntry, ch chan<- Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := make(map[string]HostEntry, len(entries))
+	for _, entry := range entries {
+		current[entry.ID] = entry
+	}
+
+	previous := r.seen[serviceName]
+	for id, entry := range current {
+		if _, existed := previous[id]; !existed {
+			ch <- Event{Type: EventAdded, Entry: entry}
+		}
+	}
+	for id, entry := range previous {
+		if _, stillThere := current[id]; !stillThere {
+			ch <- Event{Type: EventRemoved, Entry: entry}
+		}
+	}
+
+	r.seen[serviceName] = current
+}
+
+func parseMDNSEntry(entry *mdns.ServiceEntry) (HostEntry, bool) {
+	if len(entry.InfoFields) == 0 {
+		return HostEntry{}, false
+	}
+
+	var reg mdnsRegistration
+	if err := json.Unmarshal([]byte(entry.InfoFields[0]), &reg); err != nil {
+		return HostEntry{}, false
+	}
+
+	ip := entry.AddrV4
+	if ip == nil {
+		ip = entry.AddrV6
+	}
+	if ip == nil {
+		return HostEntry{}, false
+	}
+
+	return HostEntry{
+		ID:       reg.ID,
+		Addr:     net.JoinHostPort(ip.String(), fmt.Sprintf("%d", entry.Port)),
+		HostInfo: reg.HostInfo,
+	}, true
+}
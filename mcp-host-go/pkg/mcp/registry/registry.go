@@ -0,0 +1,82 @@
+// Package registry lets an MCP Server publish itself to a service discovery
+// backend so other nanobrowser hosts (and clients) can find it, and watch
+// the backend for peer hosts coming and going.
+package registry
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// ServiceName is the service name every nanobrowser MCP host registers
+// itself under, and the name peers List/Watch against.
+const ServiceName = "nanobrowser-mcp-host"
+
+// HostEntry describes one nanobrowser host instance as published to a
+// registry backend. Registry itself only carries discovery data (address,
+// HostInfo); a host's current tool names are looked up live over its own
+// list_tools RPC, so they stay accurate without re-registering on every
+// tool change.
+type HostEntry struct {
+	ID       string
+	Addr     string
+	HostInfo types.HostInfo
+}
+
+// EventType identifies what changed in a Watch notification.
+type EventType int
+
+const (
+	// EventAdded means a host newly appeared in the registry.
+	EventAdded EventType = iota
+	// EventRemoved means a host disappeared from the registry, whether by
+	// explicit deregistration or TTL expiry.
+	EventRemoved
+)
+
+// Event is delivered by Watch when a peer host is added to or removed from
+// the registry.
+type Event struct {
+	Type  EventType
+	Entry HostEntry
+}
+
+// Registry is a pluggable service discovery backend a Server can register
+// itself with on Start and deregister from on Shutdown.
+type Registry interface {
+	// Register publishes info under ServiceName at addr and returns an ID
+	// that must be passed to Deregister. The registration expires after ttl
+	// unless refreshed by calling Register again with the same addr.
+	Register(info types.HostInfo, addr string, ttl time.Duration) (string, error)
+
+	// Deregister removes a previously registered host.
+	Deregister(id string) error
+
+	// List returns every host currently registered under serviceName.
+	List(serviceName string) ([]HostEntry, error)
+
+	// Watch sends an Event to ch whenever a host is added to or removed from
+	// serviceName. Watch runs for the lifetime of the process; ch is never
+	// closed by Watch itself.
+	Watch(serviceName string, ch chan<- Event)
+}
+
+// NewFromEnv builds the Registry backend selected by the REGISTRY_BACKEND
+// env var ("mdns", "consul", or unset/"none" to disable service discovery
+// entirely). Dir-config for each backend is read from its own env vars
+// (MDNS_*, CONSUL_*) by the respective constructor.
+func NewFromEnv() (Registry, error) {
+	switch backend := os.Getenv("REGISTRY_BACKEND"); backend {
+	case "", "none":
+		return nil, nil
+	case "mdns":
+		return NewMDNSRegistry(MDNSConfig{})
+	case "consul":
+		return NewConsulRegistry(ConsulConfig{})
+	default:
+		return nil, fmt.Errorf("unknown REGISTRY_BACKEND: %s", backend)
+	}
+}
@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/repl"
+)
+
+// ListTools implements repl.Router by reading directly off the same tool
+// registry RegisterTool populates, so a REPL session observes exactly the
+// tools the MCP server itself would dispatch execute_tool against.
+func (s *Server) ListTools() ([]repl.ToolInfo, error) {
+	s.toolsMutex.RLock()
+	infos := make([]repl.ToolInfo, 0, len(s.tools))
+	for _, tool := range s.tools {
+		infos = append(infos, repl.ToolInfo{
+			Name:        tool.GetName(),
+			Description: tool.GetDescription(),
+			InputSchema: tool.GetInputSchema(),
+		})
+	}
+	s.toolsMutex.RUnlock()
+	return infos, nil
+}
+
+// ExecuteTool implements repl.Router.
+func (s *Server) ExecuteTool(name string, args map[string]interface{}) (repl.ToolResult, error) {
+	s.toolsMutex.RLock()
+	tool, exists := s.tools[name]
+	s.toolsMutex.RUnlock()
+	if !exists {
+		return repl.ToolResult{}, fmt.Errorf("tool not found: %s", name)
+	}
+
+	result, err := tool.Execute(args)
+	if err != nil {
+		return repl.ToolResult{}, err
+	}
+
+	items := make([]repl.ToolResultItem, 0, len(result.Content))
+	for _, item := range result.Content {
+		items = append(items, repl.ToolResultItem{Type: item.Type, Text: item.Text})
+	}
+	return repl.ToolResult{Content: items}, nil
+}
+
+// ReadResource implements repl.Router.
+func (s *Server) ReadResource(uri string) (interface{}, error) {
+	s.resourcesMutex.RLock()
+	resource, exists := s.resources[uri]
+	s.resourcesMutex.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("resource not found: %s", uri)
+	}
+	return resource.Read()
+}
@@ -1,26 +1,67 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/mcp/registry"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/mcpclient"
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
 )
 
+// defaultRegistryTTL is how long a service registry entry is valid for
+// before it must be refreshed; Start's heartbeat goroutine refreshes at
+// half this interval.
+const defaultRegistryTTL = 30 * time.Second
+
 // Server implements the McpServer interface
 type Server struct {
 	logger         logger.Logger
 	messaging      types.Messaging
 	resources      map[string]types.Resource
+	resourcesMutex sync.RWMutex
 	tools          map[string]types.Tool
+	toolsMutex     sync.RWMutex
 	running        bool
 	runningMutex   sync.RWMutex
 	startTime      int64
 	hostInfo       types.HostInfo
 	shutdownSignal chan struct{}
+
+	// pluginManager is non-nil once LoadPluginDir has been called, so
+	// Shutdown can terminate every plugin subprocess it started.
+	pluginManager *PluginManager
+
+	// stdioPluginManager is non-nil once LoadStdioPluginDir has been called,
+	// so Shutdown can terminate every stdio plugin subprocess it started.
+	stdioPluginManager *StdioPluginManager
+
+	// cancelFuncs holds one context.CancelFunc per in-flight StreamingTool
+	// invocation, keyed by its progress token, so a tools/cancel RPC can
+	// signal it.
+	cancelFuncs map[string]context.CancelFunc
+	cancelMutex sync.Mutex
+
+	// registry, when non-nil, publishes this Server's presence on Start and
+	// withdraws it on Shutdown so peer hosts can discover it via hosts/list.
+	registry     registry.Registry
+	registryAddr string
+	registryTTL  time.Duration
+	registryID   string
+	registryStop chan struct{}
+
+	// peerClients caches an mcpclient.McpSSEClient per peer address, used to
+	// proxy execute_tool calls to whichever host actually owns the tool and
+	// to resolve a peer's current tool names for hosts/list.
+	peerClients map[string]*mcpclient.McpSSEClient
+	peerMutex   sync.Mutex
 }
 
 // ServerConfig contains configuration for the MCP Server
@@ -28,6 +69,17 @@ type ServerConfig struct {
 	Logger    logger.Logger
 	Messaging types.Messaging
 	HostInfo  types.HostInfo
+
+	// Registry, when set, is published to on Start and withdrawn from on
+	// Shutdown so other nanobrowser hosts can discover this one. Leave nil to
+	// run standalone with no service discovery.
+	Registry registry.Registry
+	// RegistryAddr is the address this host advertises to Registry, e.g.
+	// "192.168.1.5:8080". Required if Registry is set.
+	RegistryAddr string
+	// RegistryTTL is how often the registration must be refreshed to stay
+	// alive. Defaults to defaultRegistryTTL.
+	RegistryTTL time.Duration
 }
 
 // NewServer creates a new MCP Server instance
@@ -40,6 +92,13 @@ func NewServer(config ServerConfig) (*Server, error) {
 		return nil, fmt.Errorf("messaging is required")
 	}
 
+	if config.Registry != nil && config.RegistryAddr == "" {
+		return nil, fmt.Errorf("registryAddr is required when a registry is configured")
+	}
+	if config.RegistryTTL <= 0 {
+		config.RegistryTTL = defaultRegistryTTL
+	}
+
 	server := &Server{
 		logger:         config.Logger,
 		messaging:      config.Messaging,
@@ -47,6 +106,11 @@ func NewServer(config ServerConfig) (*Server, error) {
 		tools:          make(map[string]types.Tool),
 		hostInfo:       config.HostInfo,
 		shutdownSignal: make(chan struct{}),
+		cancelFuncs:    make(map[string]context.CancelFunc),
+		registry:       config.Registry,
+		registryAddr:   config.RegistryAddr,
+		registryTTL:    config.RegistryTTL,
+		peerClients:    make(map[string]*mcpclient.McpSSEClient),
 	}
 
 	return server, nil
@@ -65,6 +129,9 @@ func (s *Server) RegisterResource(resource types.Resource) error {
 
 	s.logger.Debug("Registering resource", uri)
 
+	s.resourcesMutex.Lock()
+	defer s.resourcesMutex.Unlock()
+
 	if _, exists := s.resources[uri]; exists {
 		return fmt.Errorf("resource already registered: %s", uri)
 	}
@@ -73,6 +140,15 @@ func (s *Server) RegisterResource(resource types.Resource) error {
 	return nil
 }
 
+// UnregisterResource removes a previously registered resource, e.g. because
+// the plugin that provided it crashed or was reloaded. It is not an error to
+// unregister a URI that isn't currently registered.
+func (s *Server) UnregisterResource(uri string) {
+	s.resourcesMutex.Lock()
+	defer s.resourcesMutex.Unlock()
+	delete(s.resources, uri)
+}
+
 // RegisterTool registers a tool with the server
 func (s *Server) RegisterTool(tool types.Tool) error {
 	if tool == nil {
@@ -86,11 +162,13 @@ func (s *Server) RegisterTool(tool types.Tool) error {
 
 	s.logger.Debug("Registering tool", name)
 
+	s.toolsMutex.Lock()
 	if _, exists := s.tools[name]; exists {
+		s.toolsMutex.Unlock()
 		return fmt.Errorf("tool already registered: %s", name)
 	}
-
 	s.tools[name] = tool
+	s.toolsMutex.Unlock()
 
 	// Register the tool execute handler
 	s.messaging.RegisterRpcMethod("execute_tool", s.handleExecuteTool)
@@ -98,6 +176,76 @@ func (s *Server) RegisterTool(tool types.Tool) error {
 	return nil
 }
 
+// UnregisterTool removes a previously registered tool, e.g. because the
+// plugin that provided it crashed or was reloaded. It is not an error to
+// unregister a name that isn't currently registered.
+func (s *Server) UnregisterTool(name string) {
+	s.toolsMutex.Lock()
+	defer s.toolsMutex.Unlock()
+	delete(s.tools, name)
+}
+
+// LoadPluginDir scans dir for out-of-process tool/resource plugin binaries,
+// registers each one's tools and resources, and keeps watching dir so newly
+// dropped or rebuilt binaries are picked up without restarting the host. A
+// crashed plugin is automatically restarted with exponential backoff. Calling
+// it more than once replaces any previously loaded PluginManager.
+func (s *Server) LoadPluginDir(dir string) error {
+	manager, err := NewPluginManager(PluginManagerConfig{
+		Logger: s.logger,
+		Server: s,
+		Dir:    dir,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := manager.LoadAll(); err != nil {
+		return err
+	}
+	manager.Watch()
+
+	s.pluginManager = manager
+	return nil
+}
+
+// LoadStdioPluginDir scans dir for out-of-process plugin binaries that speak
+// the length-prefixed stdio JSON-RPC transport (pkg/plugin/stdio), registers
+// each one's tools and resources, and keeps watching dir the same way
+// LoadPluginDir does for the gRPC-based plugin subsystem. The two plugin
+// directories are independent: a binary in one is never treated as the
+// other's kind of plugin. Calling it more than once replaces any previously
+// loaded StdioPluginManager.
+func (s *Server) LoadStdioPluginDir(dir string) error {
+	manager, err := NewStdioPluginManager(StdioPluginManagerConfig{
+		Logger: s.logger,
+		Server: s,
+		Dir:    dir,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := manager.LoadAll(); err != nil {
+		return err
+	}
+	manager.Watch()
+
+	s.stdioPluginManager = manager
+	return nil
+}
+
+// lookupTool returns the tool registered under name, if any. It backs both
+// execute_tool and the stdio plugin host-callback path, which lets a stdio
+// plugin invoke an already-registered host tool such as get_browser_state or
+// navigate_to.
+func (s *Server) lookupTool(name string) (types.Tool, bool) {
+	s.toolsMutex.RLock()
+	defer s.toolsMutex.RUnlock()
+	tool, exists := s.tools[name]
+	return tool, exists
+}
+
 // Start starts the MCP server
 func (s *Server) Start() error {
 	s.runningMutex.Lock()
@@ -117,9 +265,15 @@ func (s *Server) Start() error {
 	// Register resource handlers
 	s.messaging.RegisterRpcMethod("list_resources", s.handleListResources)
 	s.messaging.RegisterRpcMethod("get_resource", s.handleGetResource)
+	s.messaging.RegisterRpcMethod("resources/subscribe", s.handleSubscribeResource)
+	s.messaging.RegisterRpcMethod("resources/unsubscribe", s.handleUnsubscribeResource)
 
 	// Register tool handlers
 	s.messaging.RegisterRpcMethod("list_tools", s.handleListTools)
+	s.messaging.RegisterRpcMethod("tools/cancel", s.handleCancelTool)
+
+	// Register host federation handler
+	s.messaging.RegisterRpcMethod("hosts/list", s.handleListHosts)
 
 	// Start message processing
 	if err := s.messaging.Start(); err != nil {
@@ -127,10 +281,54 @@ func (s *Server) Start() error {
 		return fmt.Errorf("failed to start messaging: %w", err)
 	}
 
+	if s.registry != nil {
+		if err := s.registerWithRegistry(); err != nil {
+			s.logger.Warn("Failed to register with service registry", err)
+		}
+		s.registryStop = make(chan struct{})
+		go s.heartbeatRegistry()
+	}
+
 	s.logger.Info("MCP server started")
 	return nil
 }
 
+// registerWithRegistry (re-)publishes this host to s.registry, replacing
+// registryID with whatever ID the backend returns. Calling it again, e.g.
+// from heartbeatRegistry, refreshes the TTL and recovers from a backend that
+// dropped the registration (a restarted Consul agent, for instance).
+func (s *Server) registerWithRegistry() error {
+	id, err := s.registry.Register(s.hostInfo, s.registryAddr, s.registryTTL)
+	if err != nil {
+		return err
+	}
+	s.registryID = id
+	return nil
+}
+
+// heartbeatRegistry refreshes the service registry registration at half the
+// TTL interval until registryStop is closed by Shutdown.
+func (s *Server) heartbeatRegistry() {
+	interval := s.registryTTL / 2
+	if interval <= 0 {
+		interval = defaultRegistryTTL / 2
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.registryStop:
+			return
+		case <-ticker.C:
+			if err := s.registerWithRegistry(); err != nil {
+				s.logger.Warn("Failed to refresh service registry registration", err)
+			}
+		}
+	}
+}
+
 // Shutdown shuts down the MCP server
 func (s *Server) Shutdown() error {
 	s.runningMutex.Lock()
@@ -143,6 +341,24 @@ func (s *Server) Shutdown() error {
 	s.logger.Info("Shutting down MCP server")
 	s.running = false
 	close(s.shutdownSignal)
+
+	if s.pluginManager != nil {
+		s.pluginManager.Shutdown()
+	}
+
+	if s.stdioPluginManager != nil {
+		s.stdioPluginManager.Shutdown()
+	}
+
+	if s.registry != nil {
+		if s.registryStop != nil {
+			close(s.registryStop)
+		}
+		if err := s.registry.Deregister(s.registryID); err != nil {
+			s.logger.Warn("Failed to deregister from service registry", err)
+		}
+	}
+
 	return nil
 }
 
@@ -174,6 +390,7 @@ func (s *Server) handleStatusRequest(data interface{}) error {
 func (s *Server) handleListResources(request types.RpcRequest) (types.RpcResponse, error) {
 	s.logger.Debug("Handling list_resources RPC request")
 
+	s.resourcesMutex.RLock()
 	resourceList := make([]map[string]string, 0, len(s.resources))
 	for _, resource := range s.resources {
 		resourceList = append(resourceList, map[string]string{
@@ -183,6 +400,7 @@ func (s *Server) handleListResources(request types.RpcRequest) (types.RpcRespons
 			"description": resource.GetDescription(),
 		})
 	}
+	s.resourcesMutex.RUnlock()
 
 	return types.RpcResponse{
 		Result: resourceList,
@@ -210,7 +428,9 @@ func (s *Server) handleGetResource(request types.RpcRequest) (types.RpcResponse,
 	}
 
 	// Find the requested resource
+	s.resourcesMutex.RLock()
 	resource, exists := s.resources[uri]
+	s.resourcesMutex.RUnlock()
 	if !exists {
 		return types.RpcResponse{
 			Error: &types.ErrorInfo{
@@ -236,10 +456,82 @@ func (s *Server) handleGetResource(request types.RpcRequest) (types.RpcResponse,
 	}, nil
 }
 
+// handleSubscribeResource handles a resources/subscribe RPC request,
+// starting push notifications for the given URI if its resource supports
+// subscriptions.
+func (s *Server) handleSubscribeResource(request types.RpcRequest) (types.RpcResponse, error) {
+	return s.dispatchSubscription(request, func(sub types.SubscribableResource) error {
+		return sub.Subscribe()
+	})
+}
+
+// handleUnsubscribeResource handles a resources/unsubscribe RPC request,
+// stopping push notifications for the given URI.
+func (s *Server) handleUnsubscribeResource(request types.RpcRequest) (types.RpcResponse, error) {
+	return s.dispatchSubscription(request, func(sub types.SubscribableResource) error {
+		return sub.Unsubscribe()
+	})
+}
+
+// dispatchSubscription looks up the resource named in request's uri
+// parameter and, if it implements types.SubscribableResource, applies apply
+// to it. It's shared by handleSubscribeResource and handleUnsubscribeResource
+// since the two differ only in which method they call.
+func (s *Server) dispatchSubscription(request types.RpcRequest, apply func(types.SubscribableResource) error) (types.RpcResponse, error) {
+	params, ok := request.Params.(map[string]interface{})
+	if !ok {
+		return types.RpcResponse{}, fmt.Errorf("invalid parameters format")
+	}
+
+	uriParam, ok := params["uri"]
+	if !ok {
+		return types.RpcResponse{}, fmt.Errorf("missing uri parameter")
+	}
+
+	uri, ok := uriParam.(string)
+	if !ok {
+		return types.RpcResponse{}, fmt.Errorf("uri parameter must be a string")
+	}
+
+	s.resourcesMutex.RLock()
+	resource, exists := s.resources[uri]
+	s.resourcesMutex.RUnlock()
+	if !exists {
+		return types.RpcResponse{
+			Error: &types.ErrorInfo{
+				Code:    404,
+				Message: fmt.Sprintf("Resource not found: %s", uri),
+			},
+		}, nil
+	}
+
+	subscribable, ok := resource.(types.SubscribableResource)
+	if !ok {
+		return types.RpcResponse{
+			Error: &types.ErrorInfo{
+				Code:    400,
+				Message: fmt.Sprintf("Resource does not support subscriptions: %s", uri),
+			},
+		}, nil
+	}
+
+	if err := apply(subscribable); err != nil {
+		return types.RpcResponse{
+			Error: &types.ErrorInfo{
+				Code:    500,
+				Message: err.Error(),
+			},
+		}, nil
+	}
+
+	return types.RpcResponse{Result: map[string]interface{}{"uri": uri}}, nil
+}
+
 // handleListTools handles an RPC request to list all available tools
 func (s *Server) handleListTools(request types.RpcRequest) (types.RpcResponse, error) {
 	s.logger.Debug("Handling list_tools RPC request")
 
+	s.toolsMutex.RLock()
 	toolList := make([]map[string]interface{}, 0, len(s.tools))
 	for _, tool := range s.tools {
 		toolList = append(toolList, map[string]interface{}{
@@ -248,6 +540,7 @@ func (s *Server) handleListTools(request types.RpcRequest) (types.RpcResponse, e
 			"inputSchema": tool.GetInputSchema(),
 		})
 	}
+	s.toolsMutex.RUnlock()
 
 	return types.RpcResponse{
 		Result: toolList,
@@ -305,8 +598,22 @@ func (s *Server) handleExecuteTool(request types.RpcRequest) (types.RpcResponse,
 	}
 
 	// Find the tool
-	tool, exists := s.tools[name]
+	tool, exists := s.lookupTool(name)
 	if !exists {
+		if s.registry != nil {
+			if result, proxyErr, handled := s.proxyExecuteTool(name, args); handled {
+				if proxyErr != nil {
+					return types.RpcResponse{
+						Error: &types.ErrorInfo{
+							Code:    502,
+							Message: proxyErr.Error(),
+						},
+					}, nil
+				}
+				return types.RpcResponse{Result: result}, nil
+			}
+		}
+
 		return types.RpcResponse{
 			Error: &types.ErrorInfo{
 				Code:    404,
@@ -315,6 +622,12 @@ func (s *Server) handleExecuteTool(request types.RpcRequest) (types.RpcResponse,
 		}, nil
 	}
 
+	// A StreamingTool reports progress as it runs rather than only returning
+	// once it's done, so it takes a different execution path.
+	if streamingTool, ok := tool.(types.StreamingTool); ok {
+		return s.executeStreamingTool(streamingTool, args), nil
+	}
+
 	// Execute the tool
 	result, err := tool.Execute(args)
 	if err != nil {
@@ -330,3 +643,255 @@ func (s *Server) handleExecuteTool(request types.RpcRequest) (types.RpcResponse,
 		Result: result,
 	}, nil
 }
+
+// executeStreamingTool runs a StreamingTool under a fresh progress token,
+// forwarding each event it emits as a notifications/progress message tagged
+// with that token, and registers the token's cancel func so a tools/cancel
+// RPC can interrupt the call. The same ctx is passed into ExecuteStream, so
+// a tool built on anything that honors context cancellation (e.g. a
+// chromedp call) actually stops doing work once cancelled, rather than just
+// having the host stop waiting on it.
+func (s *Server) executeStreamingTool(tool types.StreamingTool, args map[string]interface{}) types.RpcResponse {
+	token := uuid.NewString()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancelMutex.Lock()
+	s.cancelFuncs[token] = cancel
+	s.cancelMutex.Unlock()
+	defer func() {
+		s.cancelMutex.Lock()
+		delete(s.cancelFuncs, token)
+		s.cancelMutex.Unlock()
+		cancel()
+	}()
+
+	emit := func(event types.ProgressEvent) {
+		params, err := progressEventToParams(event, token)
+		if err != nil {
+			s.logger.Warn("Failed to encode progress event", err)
+			return
+		}
+		s.messaging.SendNotification("notifications/progress", params)
+	}
+
+	type streamOutcome struct {
+		result interface{}
+		err    error
+	}
+	done := make(chan streamOutcome, 1)
+	go func() {
+		result, err := tool.ExecuteStream(ctx, args, emit)
+		done <- streamOutcome{result: result, err: err}
+	}()
+
+	select {
+	case outcome := <-done:
+		if outcome.err != nil {
+			return types.RpcResponse{
+				Error: &types.ErrorInfo{
+					Code:    500,
+					Message: fmt.Sprintf("Tool execution error: %s", outcome.err.Error()),
+				},
+			}
+		}
+		return types.RpcResponse{Result: outcome.result}
+	case <-ctx.Done():
+		return types.RpcResponse{
+			Error: &types.ErrorInfo{
+				Code:    499,
+				Message: "Tool execution cancelled",
+			},
+		}
+	}
+}
+
+// progressEventToParams flattens event into the notification params, adding
+// the progressToken that ties it back to the execute_tool call that started
+// the stream.
+func progressEventToParams(event types.ProgressEvent, token string) (map[string]interface{}, error) {
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	var params map[string]interface{}
+	if err := json.Unmarshal(eventBytes, &params); err != nil {
+		return nil, err
+	}
+	params["progressToken"] = token
+	return params, nil
+}
+
+// handleCancelTool handles a tools/cancel RPC request, signaling the
+// context.CancelFunc registered for the given progress token, if its
+// StreamingTool invocation is still running.
+func (s *Server) handleCancelTool(request types.RpcRequest) (types.RpcResponse, error) {
+	params, ok := request.Params.(map[string]interface{})
+	if !ok {
+		return types.RpcResponse{}, fmt.Errorf("invalid parameters format")
+	}
+
+	tokenParam, ok := params["progressToken"]
+	if !ok {
+		return types.RpcResponse{}, fmt.Errorf("missing progressToken parameter")
+	}
+
+	token, ok := tokenParam.(string)
+	if !ok {
+		return types.RpcResponse{}, fmt.Errorf("progressToken parameter must be a string")
+	}
+
+	s.cancelMutex.Lock()
+	cancel, exists := s.cancelFuncs[token]
+	s.cancelMutex.Unlock()
+
+	if !exists {
+		return types.RpcResponse{
+			Error: &types.ErrorInfo{
+				Code:    404,
+				Message: fmt.Sprintf("No running tool for progress token: %s", token),
+			},
+		}, nil
+	}
+
+	cancel()
+	return types.RpcResponse{Result: map[string]bool{"cancelled": true}}, nil
+}
+
+// handleListHosts handles a hosts/list RPC request, enumerating every peer
+// registered under registry.ServiceName along with its current tool names
+// and HostInfo. Tool names are resolved live over each peer's own
+// list_tools RPC rather than stored in the registry, so they stay accurate
+// without the peer having to re-register every time its tool set changes.
+func (s *Server) handleListHosts(request types.RpcRequest) (types.RpcResponse, error) {
+	s.logger.Debug("Handling hosts/list RPC request")
+
+	if s.registry == nil {
+		return types.RpcResponse{Result: []interface{}{}}, nil
+	}
+
+	entries, err := s.registry.List(registry.ServiceName)
+	if err != nil {
+		return types.RpcResponse{}, fmt.Errorf("failed to list hosts: %w", err)
+	}
+
+	hostList := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		hostList = append(hostList, map[string]interface{}{
+			"id":       entry.ID,
+			"addr":     entry.Addr,
+			"hostInfo": entry.HostInfo,
+			"tools":    s.toolNamesFor(entry),
+		})
+	}
+
+	return types.RpcResponse{
+		Result: hostList,
+	}, nil
+}
+
+// toolNamesFor returns entry's currently advertised tool names: straight
+// from s.tools if entry is this host, otherwise via a live list_tools call
+// to its peer. Any failure to reach a peer yields an empty list rather than
+// failing the whole hosts/list request.
+func (s *Server) toolNamesFor(entry registry.HostEntry) []string {
+	if entry.ID == s.registryID {
+		s.toolsMutex.RLock()
+		defer s.toolsMutex.RUnlock()
+		names := make([]string, 0, len(s.tools))
+		for name := range s.tools {
+			names = append(names, name)
+		}
+		return names
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := s.connectedPeerClient(ctx, entry.Addr)
+	if err != nil {
+		s.logger.Warn("Failed to connect to peer host", entry.Addr, err)
+		return nil
+	}
+
+	resp, err := client.ListTools(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to list tools on peer host", entry.Addr, err)
+		return nil
+	}
+
+	names := make([]string, 0, len(resp.Tools))
+	for _, tool := range resp.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+// proxyExecuteTool looks for a peer registered under registry.ServiceName
+// that owns the given tool name and, if found, forwards the call to it over
+// its SSE MCP endpoint. handled is false if no peer advertises the tool, so
+// the caller can fall back to its own "tool not found" response. A peer
+// that reports back its own "tool not found" is skipped in favor of the
+// next one; any other failure from the peer that actually owns the tool is
+// returned as err rather than masked as a missing tool.
+func (s *Server) proxyExecuteTool(name string, args map[string]interface{}) (result interface{}, err error, handled bool) {
+	entries, listErr := s.registry.List(registry.ServiceName)
+	if listErr != nil {
+		return nil, fmt.Errorf("failed to list hosts for proxying: %w", listErr), true
+	}
+
+	for _, entry := range entries {
+		if entry.ID == s.registryID {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		client, connErr := s.connectedPeerClient(ctx, entry.Addr)
+		if connErr != nil {
+			cancel()
+			continue
+		}
+
+		resp, callErr := client.CallTool(ctx, name, args)
+		cancel()
+		if callErr != nil {
+			var rpcErr *mcpclient.RPCError
+			if errors.As(callErr, &rpcErr) && rpcErr.Code == 404 {
+				// This peer confirmed it doesn't have the tool; keep
+				// looking at the next one before giving up.
+				continue
+			}
+			// The peer that actually owns the tool failed to run it (or the
+			// call itself failed); surface that instead of masking it as a
+			// generic "tool not found" once every peer has been tried.
+			return nil, fmt.Errorf("peer %s failed to execute tool %q: %w", entry.Addr, name, callErr), true
+		}
+
+		return resp, nil, true
+	}
+
+	return nil, nil, false
+}
+
+// connectedPeerClient returns the cached McpSSEClient for addr, connecting
+// it first if this is the first time addr has been seen.
+func (s *Server) connectedPeerClient(ctx context.Context, addr string) (*mcpclient.McpSSEClient, error) {
+	s.peerMutex.Lock()
+	client, exists := s.peerClients[addr]
+	if !exists {
+		client = mcpclient.NewMcpSSEClient(addr)
+		s.peerClients[addr] = client
+	}
+	s.peerMutex.Unlock()
+
+	if !exists {
+		if err := client.Connect(ctx); err != nil {
+			s.peerMutex.Lock()
+			delete(s.peerClients, addr)
+			s.peerMutex.Unlock()
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
@@ -0,0 +1,371 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/plugin/stdio"
+)
+
+const (
+	stdioPluginManifestTimeout = 10 * time.Second
+	stdioPluginShutdownGrace   = 5 * time.Second
+)
+
+// StdioPluginManagerConfig contains configuration for StdioPluginManager
+type StdioPluginManagerConfig struct {
+	Logger logger.Logger
+	Server *Server
+
+	// Dir is scanned for executable stdio plugin binaries by LoadAll; each
+	// discovered binary is launched as its own subprocess.
+	Dir string
+
+	// CallTimeout bounds how long a tools/call or resources/read RPC to a
+	// plugin may take, the same role RpcOptions{Timeout} plays for
+	// host-to-extension RPCs. Defaults to defaultStdioPluginCallTimeout.
+	CallTimeout time.Duration
+}
+
+// stdioPluginInstance tracks everything StdioPluginManager needs to restart
+// or tear down a single plugin binary.
+type stdioPluginInstance struct {
+	path         string
+	client       *stdio.Client
+	toolNames    []string
+	resourceURIs []string
+
+	stopMonitor chan struct{}
+}
+
+// StdioPluginManager discovers out-of-process plugin binaries that speak the
+// length-prefixed stdio JSON-RPC transport (pkg/plugin/stdio) and bridges
+// each one's tools and resources into Server, the same role PluginManager
+// plays for the gRPC-based plugin subsystem. Unlike that subsystem, a stdio
+// plugin may call back into the host over the same stream to invoke an
+// already-registered host tool such as navigate_to. Only tools registered on
+// Server are reachable this way; a raw extension RPC method such as
+// get_browser_state is answered with a "host tool not found" error.
+type StdioPluginManager struct {
+	logger      logger.Logger
+	server      *Server
+	dir         string
+	callTimeout time.Duration
+
+	mu           sync.Mutex
+	instances    map[string]*stdioPluginInstance
+	shuttingDown bool
+
+	watcher *fsnotify.Watcher
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewStdioPluginManager creates a new StdioPluginManager
+func NewStdioPluginManager(config StdioPluginManagerConfig) (*StdioPluginManager, error) {
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if config.Server == nil {
+		return nil, fmt.Errorf("server is required")
+	}
+	if config.CallTimeout <= 0 {
+		config.CallTimeout = defaultStdioPluginCallTimeout
+	}
+
+	return &StdioPluginManager{
+		logger:      config.Logger,
+		server:      config.Server,
+		dir:         config.Dir,
+		callTimeout: config.CallTimeout,
+		instances:   make(map[string]*stdioPluginInstance),
+		stopCh:      make(chan struct{}),
+	}, nil
+}
+
+// LoadAll discovers every executable file directly inside Dir and launches
+// each as a stdio plugin subprocess, registering its tools and resources
+// onto Server. A missing Dir is not an error: plugins are an optional
+// extension point.
+func (m *StdioPluginManager) LoadAll() error {
+	if m.dir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read stdio plugin directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Mode()&0111 == 0 {
+			continue
+		}
+
+		path := filepath.Join(m.dir, entry.Name())
+		if err := m.load(path); err != nil {
+			m.logger.Error("Failed to load stdio plugin, skipping", zap.String("path", path), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// load launches one plugin binary, registers its tools and resources, and
+// starts a background goroutine that watches for the subprocess exiting.
+func (m *StdioPluginManager) load(path string) error {
+	instance, err := m.start(path)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.instances[path] = instance
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go m.monitor(instance)
+
+	return nil
+}
+
+// start launches the plugin binary at path, waits for its manifest, and
+// registers its tools and resources, returning the resulting instance
+// without touching m.instances (so restart can swap it in atomically).
+func (m *StdioPluginManager) start(path string) (*stdioPluginInstance, error) {
+	client, err := stdio.Start(path, m.hostCallHandler)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start stdio plugin: %w", err)
+	}
+
+	manifest, err := client.WaitForManifest(stdioPluginManifestTimeout)
+	if err != nil {
+		client.Close(stdioPluginShutdownGrace)
+		return nil, fmt.Errorf("failed to read stdio plugin manifest: %w", err)
+	}
+
+	instance := &stdioPluginInstance{path: path, client: client, stopMonitor: make(chan struct{})}
+
+	for _, tool := range manifest.Tools {
+		if err := m.server.RegisterTool(newStdioPluginTool(tool, client, m.logger, m.callTimeout)); err != nil {
+			m.logger.Error("Failed to register stdio plugin tool", zap.String("path", path), zap.String("tool", tool.Name), zap.Error(err))
+			continue
+		}
+		instance.toolNames = append(instance.toolNames, tool.Name)
+	}
+
+	for _, resource := range manifest.Resources {
+		if err := m.server.RegisterResource(newStdioPluginResource(resource, client, m.logger, m.callTimeout)); err != nil {
+			m.logger.Error("Failed to register stdio plugin resource", zap.String("path", path), zap.String("uri", resource.URI), zap.Error(err))
+			continue
+		}
+		instance.resourceURIs = append(instance.resourceURIs, resource.URI)
+	}
+
+	m.logger.Info("Loaded stdio plugin",
+		zap.String("path", path),
+		zap.Int("tools", len(instance.toolNames)),
+		zap.Int("resources", len(instance.resourceURIs)))
+
+	return instance, nil
+}
+
+// hostCallHandler serves an RPC a plugin issues back into the host, e.g. to
+// invoke navigate_to, by dispatching it to whichever tool is already
+// registered on Server under that name. A method that names no registered
+// tool returns an error; it is not forwarded to the browser driver.
+func (m *StdioPluginManager) hostCallHandler(method string, params json.RawMessage) (interface{}, error) {
+	tool, ok := m.server.lookupTool(method)
+	if !ok {
+		return nil, fmt.Errorf("host tool %q not found", method)
+	}
+
+	var args map[string]interface{}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, fmt.Errorf("invalid arguments for host tool %q: %w", method, err)
+		}
+	}
+
+	return tool.Execute(args)
+}
+
+// unregister removes every tool and resource an instance registered, e.g.
+// before restarting or reloading it.
+func (m *StdioPluginManager) unregister(instance *stdioPluginInstance) {
+	for _, name := range instance.toolNames {
+		m.server.UnregisterTool(name)
+	}
+	for _, uri := range instance.resourceURIs {
+		m.server.UnregisterResource(uri)
+	}
+}
+
+// monitor waits for instance.client to report its stream closed (the
+// process crashed or exited) and restarts the plugin with exponential
+// backoff, until Shutdown or a reload replaces it with a fresh instance.
+func (m *StdioPluginManager) monitor(instance *stdioPluginInstance) {
+	defer m.wg.Done()
+
+	select {
+	case <-m.stopCh:
+		return
+	case <-instance.stopMonitor:
+		return
+	case <-instance.client.Done():
+	}
+
+	m.logger.Error("Stdio plugin exited, unregistering and restarting", zap.String("path", instance.path))
+	m.unregister(instance)
+
+	backoff := newRestartBackoff()
+	for {
+		delay := backoff.next()
+		select {
+		case <-m.stopCh:
+			return
+		case <-instance.stopMonitor:
+			// reload already replaced this instance; restarting it here
+			// would overwrite the fresh one in m.instances.
+			return
+		case <-time.After(delay):
+		}
+
+		restarted, err := m.start(instance.path)
+		if err == nil {
+			m.mu.Lock()
+			if m.shuttingDown {
+				// Shutdown ran (and swept m.instances) while this restart
+				// was in flight. Inserting restarted now would orphan it:
+				// Shutdown already closed the instances it saw and is past
+				// the point of looking again. Close the freshly spawned
+				// process immediately instead of registering it.
+				m.mu.Unlock()
+				restarted.client.Close(stdioPluginShutdownGrace)
+				return
+			}
+			m.instances[instance.path] = restarted
+			m.mu.Unlock()
+			m.wg.Add(1)
+			go m.monitor(restarted)
+			return
+		}
+
+		m.logger.Error("Failed to restart exited stdio plugin, backing off",
+			zap.String("path", instance.path), zap.Duration("backoff", delay), zap.Error(err))
+	}
+}
+
+// Watch starts an fsnotify watch on Dir so a plugin binary that's rebuilt or
+// newly dropped in is (re)loaded without restarting the host. Watch is a
+// no-op if Dir is empty; failures to establish the watch are logged rather
+// than returned, since hot reload is a convenience on top of LoadAll.
+func (m *StdioPluginManager) Watch() {
+	if m.dir == "" {
+		return
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		m.logger.Error("Failed to start stdio plugin directory watcher", zap.Error(err))
+		return
+	}
+	if err := watcher.Add(m.dir); err != nil {
+		m.logger.Error("Failed to watch stdio plugin directory", zap.String("dir", m.dir), zap.Error(err))
+		watcher.Close()
+		return
+	}
+
+	m.watcher = watcher
+	m.wg.Add(1)
+	go m.watchLoop(watcher)
+}
+
+func (m *StdioPluginManager) watchLoop(watcher *fsnotify.Watcher) {
+	defer m.wg.Done()
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			m.reload(event.Name)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			m.logger.Error("Stdio plugin directory watch error", zap.Error(err))
+		}
+	}
+}
+
+// reload tears down any existing instance for path and starts a fresh one,
+// e.g. because the binary on disk changed.
+func (m *StdioPluginManager) reload(path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	existing := m.instances[path]
+	delete(m.instances, path)
+	m.mu.Unlock()
+
+	if existing != nil {
+		close(existing.stopMonitor)
+		m.unregister(existing)
+		existing.client.Close(stdioPluginShutdownGrace)
+	}
+
+	m.logger.Info("Reloading stdio plugin", zap.String("path", path))
+	if err := m.load(path); err != nil {
+		m.logger.Error("Failed to reload stdio plugin", zap.String("path", path), zap.Error(err))
+	}
+}
+
+// Shutdown gracefully terminates every loaded plugin subprocess and stops
+// the directory watcher, if one was started. shuttingDown is set under the
+// same lock monitor's restart path checks before registering a restarted
+// instance, so a restart racing this call is closed immediately instead of
+// being inserted into a map Shutdown has already swept and orphaned.
+func (m *StdioPluginManager) Shutdown() {
+	close(m.stopCh)
+
+	if m.watcher != nil {
+		m.watcher.Close()
+	}
+
+	m.mu.Lock()
+	m.shuttingDown = true
+	instances := m.instances
+	m.instances = make(map[string]*stdioPluginInstance)
+	m.mu.Unlock()
+
+	for _, instance := range instances {
+		instance.client.Close(stdioPluginShutdownGrace)
+	}
+
+	m.wg.Wait()
+}
@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/plugin/stdio"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// stdioPluginResource adapts one resource advertised in a stdio plugin's
+// manifest into types.Resource, the same role pluginResource plays for the
+// gRPC-based plugin subsystem.
+type stdioPluginResource struct {
+	resource stdio.ResourceManifest
+	client   *stdio.Client
+	logger   logger.Logger
+	timeout  time.Duration
+}
+
+func newStdioPluginResource(resource stdio.ResourceManifest, client *stdio.Client, log logger.Logger, timeout time.Duration) *stdioPluginResource {
+	return &stdioPluginResource{resource: resource, client: client, logger: log, timeout: timeout}
+}
+
+// GetURI returns the resource URI
+func (r *stdioPluginResource) GetURI() string {
+	return r.resource.URI
+}
+
+// GetName returns the resource name
+func (r *stdioPluginResource) GetName() string {
+	return r.resource.Name
+}
+
+// GetMimeType returns the resource MIME type
+func (r *stdioPluginResource) GetMimeType() string {
+	return r.resource.MimeType
+}
+
+// GetDescription returns the resource description
+func (r *stdioPluginResource) GetDescription() string {
+	return r.resource.Description
+}
+
+// Read reads the resource with no arguments
+func (r *stdioPluginResource) Read() (types.ResourceContent, error) {
+	return r.ReadWithArguments(r.resource.URI, nil)
+}
+
+type stdioResourceReadResult struct {
+	Contents []types.ResourceItem `json:"contents"`
+}
+
+// ReadWithArguments calls resources/read on the owning plugin process over
+// its stdio JSON-RPC stream, the same crash-isolation tradeoff as
+// pluginResource.ReadWithArguments.
+func (r *stdioPluginResource) ReadWithArguments(uri string, arguments map[string]any) (types.ResourceContent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	raw, err := r.client.Call(ctx, "resources/read", map[string]interface{}{"uri": uri, "arguments": arguments}, r.timeout)
+	if err != nil {
+		r.logger.Error("Stdio plugin resource read failed", zap.String("uri", uri), zap.Error(err))
+		return types.ResourceContent{}, fmt.Errorf("stdio plugin resource %s failed: %w", uri, err)
+	}
+
+	var result stdioResourceReadResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return types.ResourceContent{}, fmt.Errorf("stdio plugin resource %s returned malformed result: %w", uri, err)
+	}
+
+	return types.ResourceContent{Contents: result.Contents}, nil
+}
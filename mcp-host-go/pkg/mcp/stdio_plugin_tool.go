@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/plugin/stdio"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// defaultStdioPluginCallTimeout is used when StdioPluginManagerConfig.CallTimeout
+// is left unset, matching the 30 second cap fill_form.go uses for its own
+// slowest RpcOptions{Timeout} call.
+const defaultStdioPluginCallTimeout = 30 * time.Second
+
+// stdioPluginTool adapts one tool advertised in a stdio plugin's manifest
+// into types.Tool, the same role pluginTool plays for the gRPC-based plugin
+// subsystem.
+type stdioPluginTool struct {
+	tool    stdio.ToolManifest
+	client  *stdio.Client
+	logger  logger.Logger
+	timeout time.Duration
+}
+
+func newStdioPluginTool(tool stdio.ToolManifest, client *stdio.Client, log logger.Logger, timeout time.Duration) *stdioPluginTool {
+	return &stdioPluginTool{tool: tool, client: client, logger: log, timeout: timeout}
+}
+
+// GetName returns the tool name
+func (t *stdioPluginTool) GetName() string {
+	return t.tool.Name
+}
+
+// GetDescription returns the tool description
+func (t *stdioPluginTool) GetDescription() string {
+	return t.tool.Description
+}
+
+// GetInputSchema returns the tool input schema
+func (t *stdioPluginTool) GetInputSchema() interface{} {
+	return t.tool.InputSchema
+}
+
+type stdioToolCallResult struct {
+	Content []types.ToolResultItem `json:"content"`
+}
+
+// Execute calls tools/call on the owning plugin process over its stdio
+// JSON-RPC stream. A transport-level error (e.g. the plugin crashed) is
+// logged and returned as a normal tool failure, matching pluginTool.Execute.
+func (t *stdioPluginTool) Execute(args map[string]interface{}) (types.ToolResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+
+	raw, err := t.client.Call(ctx, "tools/call", map[string]interface{}{"name": t.tool.Name, "arguments": args}, t.timeout)
+	if err != nil {
+		t.logger.Error("Stdio plugin tool call failed", zap.String("tool", t.tool.Name), zap.Error(err))
+		return types.ToolResult{}, fmt.Errorf("stdio plugin tool %s failed: %w", t.tool.Name, err)
+	}
+
+	var result stdioToolCallResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return types.ToolResult{}, fmt.Errorf("stdio plugin tool %s returned malformed result: %w", t.tool.Name, err)
+	}
+
+	return types.ToolResult{Content: result.Content}, nil
+}
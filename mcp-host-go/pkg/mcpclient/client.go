@@ -0,0 +1,449 @@
+// Package mcpclient is a real client for the MCP Server-Sent Events
+// transport served by pkg/sse.SSEServer (itself a thin wrapper around
+// mark3labs/mcp-go's server.SSEServer). Unlike a plain request/response HTTP
+// client, the MCP SSE transport is asymmetric: the client opens one
+// long-lived GET request to receive server-pushed frames, and issues calls
+// as separate HTTP POSTs against a per-session endpoint the server only
+// reveals over that stream. This package implements both halves plus
+// reconnection, so integration tests can exercise the transport the same
+// way a real MCP client would instead of talking to a single request/response
+// endpoint that doesn't exist on the wire.
+package mcpclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NotificationHandler is invoked for a server-initiated JSON-RPC
+// notification, e.g. "notifications/resources/updated",
+// "notifications/tools/list_changed", or "notifications/progress".
+type NotificationHandler func(params json.RawMessage)
+
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int64       `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError is a JSON-RPC error response returned by call, exported so a
+// caller can distinguish error kinds by Code (e.g. the host's own
+// "Tool not found" 404) instead of treating every call failure identically.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("mcp error %d: %s", e.Code, e.Message)
+}
+
+// Wire message shapes for the tool/resource RPC methods exposed by
+// pkg/mcp.Server. Kept local to this package (rather than pkg/types) so the
+// client has no dependency on the host's internal types, matching the
+// independence pkg/plugin keeps from the host process.
+type ListResourcesResponse struct {
+	Resources []Resource `json:"resources"`
+}
+
+type Resource struct {
+	URI         string            `json:"uri"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	MimeType    string            `json:"mimeType,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+type ListToolsResponse struct {
+	Tools []Tool `json:"tools"`
+}
+
+type Tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+type CallToolRequest struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+type CallToolResponse struct {
+	Content []ToolResponseContent `json:"content"`
+	IsError bool                  `json:"isError,omitempty"`
+}
+
+type ToolResponseContent struct {
+	Type string      `json:"type"`
+	Text string      `json:"text,omitempty"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+type ReadResourceRequest struct {
+	URI string `json:"uri"`
+}
+
+type ReadResourceResponse struct {
+	Contents []ResourceContent `json:"contents"`
+}
+
+type ResourceContent struct {
+	URI      string      `json:"uri"`
+	MimeType string      `json:"mimeType,omitempty"`
+	Text     string      `json:"text,omitempty"`
+	Blob     string      `json:"blob,omitempty"`
+	Data     interface{} `json:"data,omitempty"`
+}
+
+// McpSSEClient is a real client for the MCP SSE transport: it holds a
+// long-lived GET /sse connection for server-pushed frames (the "endpoint"
+// event and JSON-RPC "message" events) and issues requests as HTTP POSTs
+// against the per-session endpoint the server advertises over that stream.
+type McpSSEClient struct {
+	baseURL    string
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	endpointURL string
+	pending     map[int64]chan *rpcResponse
+	handlers    map[string]NotificationHandler
+	lastEventID string
+
+	nextID atomic.Int64
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	connected chan struct{}
+	closed    atomic.Bool
+}
+
+// NewMcpSSEClient creates a client that will connect to the SSE endpoint
+// rooted at baseURL, e.g. "http://localhost:8080/mcp".
+func NewMcpSSEClient(baseURL string) *McpSSEClient {
+	return &McpSSEClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{},
+		pending:    make(map[int64]chan *rpcResponse),
+		handlers:   make(map[string]NotificationHandler),
+		connected:  make(chan struct{}),
+	}
+}
+
+// RegisterNotificationHandler registers a handler for a server-initiated
+// notification method. Registering again under the same method replaces the
+// previous handler.
+func (c *McpSSEClient) RegisterNotificationHandler(method string, handler NotificationHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers[method] = handler
+}
+
+// Connect opens the SSE stream in the background and blocks until the
+// server's "endpoint" event has been received, or ctx is done, or 30 seconds
+// pass without one arriving. Once connected the stream is kept alive for the
+// lifetime of the client, reconnecting automatically (replaying from
+// lastEventID, when the server supplied one) if it drops.
+func (c *McpSSEClient) Connect(ctx context.Context) error {
+	c.ctx, c.cancel = context.WithCancel(ctx)
+
+	go c.run(c.ctx)
+
+	connectCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	select {
+	case <-c.connected:
+		return nil
+	case <-connectCtx.Done():
+		return fmt.Errorf("timed out waiting for MCP SSE endpoint event")
+	}
+}
+
+// Initialize connects to the server. It exists so McpSSEClient satisfies the
+// same shape the test environment already drives its mock client through.
+func (c *McpSSEClient) Initialize(ctx context.Context) error {
+	return c.Connect(ctx)
+}
+
+// run keeps the SSE stream alive for the lifetime of ctx, reconnecting with
+// a backoff whenever the previous connection ends without ctx being done.
+func (c *McpSSEClient) run(ctx context.Context) {
+	backoff := 500 * time.Millisecond
+	const maxBackoff = 10 * time.Second
+
+	for ctx.Err() == nil {
+		if err := c.streamOnce(ctx); err != nil && ctx.Err() == nil {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+			if backoff < maxBackoff {
+				backoff *= 2
+			}
+			continue
+		}
+		backoff = 500 * time.Millisecond
+	}
+}
+
+// streamOnce opens a single GET /sse request and processes frames from it
+// until the connection ends or ctx is cancelled.
+func (c *McpSSEClient) streamOnce(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/sse", nil)
+	if err != nil {
+		return fmt.Errorf("failed to build SSE request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+
+	c.mu.Lock()
+	lastEventID := c.lastEventID
+	c.mu.Unlock()
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SSE stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected SSE status code: %d", resp.StatusCode)
+	}
+
+	return c.readFrames(resp.Body)
+}
+
+// readFrames parses "event:"/"data:"/"id:" lines, one blank-line-terminated
+// frame at a time, dispatching each completed frame to handleFrame.
+func (c *McpSSEClient) readFrames(body io.Reader) error {
+	reader := bufio.NewReader(body)
+	var event, data, id string
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				if event != "" && data != "" {
+					c.handleFrame(event, data, id)
+				}
+				return io.EOF
+			}
+			return err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if event != "" && data != "" {
+				c.handleFrame(event, data, id)
+			}
+			event, data = "", ""
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		case strings.HasPrefix(line, "id:"):
+			id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+			c.mu.Lock()
+			c.lastEventID = id
+			c.mu.Unlock()
+		}
+	}
+}
+
+// handleFrame dispatches a completed SSE frame: "endpoint" frames resolve
+// the per-session POST URL and signal that Connect can return; "message"
+// frames are either a response correlated by JSON-RPC id, or (when id is
+// absent) a server-initiated notification routed to a registered handler.
+func (c *McpSSEClient) handleFrame(event, data, id string) {
+	switch event {
+	case "endpoint":
+		endpoint, err := url.Parse(c.baseURL)
+		if err != nil {
+			return
+		}
+		resolved, err := endpoint.Parse(data)
+		if err != nil {
+			return
+		}
+
+		c.mu.Lock()
+		wasConnected := c.endpointURL != ""
+		c.endpointURL = resolved.String()
+		c.mu.Unlock()
+
+		if !wasConnected {
+			close(c.connected)
+		}
+
+	case "message":
+		var msg rpcResponse
+		if err := json.Unmarshal([]byte(data), &msg); err != nil {
+			return
+		}
+
+		if msg.ID == nil {
+			c.mu.Lock()
+			handler := c.handlers[msg.Method]
+			c.mu.Unlock()
+			if handler != nil {
+				handler(msg.Params)
+			}
+			return
+		}
+
+		c.mu.Lock()
+		ch, ok := c.pending[*msg.ID]
+		if ok {
+			delete(c.pending, *msg.ID)
+		}
+		c.mu.Unlock()
+
+		if ok {
+			ch <- &msg
+		}
+	}
+}
+
+// call sends a JSON-RPC request to the session endpoint and waits for the
+// correlated response on the SSE stream, honoring ctx's deadline.
+func (c *McpSSEClient) call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	c.mu.Lock()
+	endpoint := c.endpointURL
+	c.mu.Unlock()
+	if endpoint == "" {
+		return nil, fmt.Errorf("not connected: call Connect first")
+	}
+
+	id := c.nextID.Add(1)
+	respCh := make(chan *rpcResponse, 1)
+
+	c.mu.Lock()
+	c.pending[id] = respCh
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	payload, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to POST request: %w", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected HTTP status posting %s: %d", method, resp.StatusCode)
+	}
+
+	select {
+	case msg := <-respCh:
+		if msg.Error != nil {
+			return nil, msg.Error
+		}
+		return msg.Result, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out waiting for %s response: %w", method, ctx.Err())
+	}
+}
+
+// ListResources calls the resources/list RPC method.
+func (c *McpSSEClient) ListResources(ctx context.Context) (*ListResourcesResponse, error) {
+	result, err := c.call(ctx, "resources/list", nil)
+	if err != nil {
+		return nil, err
+	}
+	var out ListResourcesResponse
+	if err := json.Unmarshal(result, &out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resources/list response: %w", err)
+	}
+	return &out, nil
+}
+
+// ReadResource calls the resources/read RPC method.
+func (c *McpSSEClient) ReadResource(ctx context.Context, uri string) (*ReadResourceResponse, error) {
+	result, err := c.call(ctx, "resources/read", ReadResourceRequest{URI: uri})
+	if err != nil {
+		return nil, err
+	}
+	var out ReadResourceResponse
+	if err := json.Unmarshal(result, &out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resources/read response: %w", err)
+	}
+	return &out, nil
+}
+
+// ListTools calls the tools/list RPC method.
+func (c *McpSSEClient) ListTools(ctx context.Context) (*ListToolsResponse, error) {
+	result, err := c.call(ctx, "tools/list", nil)
+	if err != nil {
+		return nil, err
+	}
+	var out ListToolsResponse
+	if err := json.Unmarshal(result, &out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tools/list response: %w", err)
+	}
+	return &out, nil
+}
+
+// CallTool calls the tools/call RPC method.
+func (c *McpSSEClient) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (*CallToolResponse, error) {
+	result, err := c.call(ctx, "tools/call", CallToolRequest{Name: name, Arguments: arguments})
+	if err != nil {
+		return nil, err
+	}
+	var out CallToolResponse
+	if err := json.Unmarshal(result, &out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tools/call response: %w", err)
+	}
+	return &out, nil
+}
+
+// Close tears down the SSE stream and releases its background goroutine.
+func (c *McpSSEClient) Close() error {
+	if c.closed.CompareAndSwap(false, true) && c.cancel != nil {
+		c.cancel()
+	}
+	return nil
+}
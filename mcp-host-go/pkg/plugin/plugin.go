@@ -0,0 +1,105 @@
+// Package plugin is the SDK third parties use to ship out-of-process
+// nanobrowser tools: implement Implementation and call Serve from main().
+// The host discovers and loads these binaries via pkg/mcp.PluginManager.
+package plugin
+
+import (
+	"context"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/plugin/proto"
+)
+
+// Handshake is the shared handshake config every nanobrowser plugin and the
+// host must agree on; a mismatch (e.g. a plugin built against a stale SDK)
+// fails fast with a clear error instead of surfacing as a confusing wire
+// decode error further down.
+var Handshake = goplugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "NANOBROWSER_PLUGIN",
+	MagicCookieValue: "nanobrowser-plugin-v1",
+}
+
+// PluginName is the key both the plugin binary and the host must use when
+// registering/dispensing this plugin in go-plugin's plugin map.
+const PluginName = "tool_plugin"
+
+// Tool mirrors pkg/types.Tool, but as a plain value instead of an interface
+// so it can cross the gRPC boundary.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]interface{}
+}
+
+// Resource mirrors pkg/types.Resource as a plain value.
+type Resource struct {
+	URI         string
+	Name        string
+	MimeType    string
+	Description string
+}
+
+// ToolResultItem mirrors pkg/types.ToolResultItem.
+type ToolResultItem struct {
+	Type string
+	Text string
+}
+
+// ResourceItem mirrors pkg/types.ResourceItem.
+type ResourceItem struct {
+	URI      string
+	MimeType string
+	Text     string
+}
+
+// Implementation is what a plugin author implements; Serve wires it up to
+// go-plugin's gRPC transport as the nanobrowser.PluginV1 service.
+type Implementation interface {
+	ListTools(ctx context.Context) ([]Tool, error)
+	CallTool(ctx context.Context, name string, args map[string]interface{}) ([]ToolResultItem, error)
+	ListResources(ctx context.Context) ([]Resource, error)
+	ReadResource(ctx context.Context, uri string, arguments map[string]interface{}) ([]ResourceItem, error)
+	// HandleRpc lets a plugin answer arbitrary RPC methods the way the
+	// host's own types.Messaging.RegisterRpcMethod handlers do, for
+	// extensions that aren't shaped like a tool or resource at all.
+	HandleRpc(ctx context.Context, method string, params map[string]interface{}) (interface{}, error)
+}
+
+// GRPCPlugin adapts an Implementation to go-plugin's plugin.GRPCPlugin
+// interface. A plugin binary constructs one with Impl set and passes it to
+// Serve; the host constructs one with Impl left nil purely to dispense a
+// PluginV1Client on the client side.
+type GRPCPlugin struct {
+	goplugin.NetRPCUnsupportedPlugin
+	Impl Implementation
+}
+
+// GRPCServer registers Impl's PluginV1 implementation onto s. Called by
+// go-plugin inside the plugin subprocess.
+func (p *GRPCPlugin) GRPCServer(broker *goplugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterPluginV1Server(s, &grpcServer{impl: p.Impl})
+	return nil
+}
+
+// GRPCClient returns a PluginV1Client bound to cc. Called by go-plugin on
+// the host side after Dispense.
+func (p *GRPCPlugin) GRPCClient(ctx context.Context, broker *goplugin.GRPCBroker, cc *grpc.ClientConn) (interface{}, error) {
+	return proto.NewPluginV1Client(cc), nil
+}
+
+// Serve starts impl as a go-plugin gRPC plugin. Call this from a plugin
+// binary's main(), e.g.:
+//
+//	func main() { plugin.Serve(myImplementation{}) }
+func Serve(impl Implementation) {
+	goplugin.Serve(&goplugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins: map[string]goplugin.Plugin{
+			PluginName: &GRPCPlugin{Impl: impl},
+		},
+		GRPCServer: goplugin.DefaultGRPCServer,
+	})
+}
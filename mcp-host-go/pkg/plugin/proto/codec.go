@@ -0,0 +1,30 @@
+package proto
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodec replaces grpc-go's default "proto" codec with plain JSON
+// marshaling, so PluginV1's messages can stay ordinary Go structs instead of
+// requiring a protoc-generated proto.Message implementation. Both client and
+// server sides import this package, so the override is in effect on both
+// ends of the connection.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "proto"
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -0,0 +1,174 @@
+package proto
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// serviceName is the fully-qualified gRPC service name from plugin.proto.
+const serviceName = "nanobrowser.PluginV1"
+
+// PluginV1Server is implemented by a plugin binary to answer the host's
+// ListTools/CallTool/ListResources/ReadResource/HandleRpc calls.
+type PluginV1Server interface {
+	ListTools(context.Context, *ListToolsRequest) (*ListToolsResponse, error)
+	CallTool(context.Context, *CallToolRequest) (*CallToolResponse, error)
+	ListResources(context.Context, *ListResourcesRequest) (*ListResourcesResponse, error)
+	ReadResource(context.Context, *ReadResourceRequest) (*ReadResourceResponse, error)
+	HandleRpc(context.Context, *HandleRpcRequest) (*HandleRpcResponse, error)
+}
+
+// PluginV1Client is implemented by the host to call into a running plugin
+// subprocess over its go-plugin gRPC broker connection.
+type PluginV1Client interface {
+	ListTools(ctx context.Context, in *ListToolsRequest, opts ...grpc.CallOption) (*ListToolsResponse, error)
+	CallTool(ctx context.Context, in *CallToolRequest, opts ...grpc.CallOption) (*CallToolResponse, error)
+	ListResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (*ListResourcesResponse, error)
+	ReadResource(ctx context.Context, in *ReadResourceRequest, opts ...grpc.CallOption) (*ReadResourceResponse, error)
+	HandleRpc(ctx context.Context, in *HandleRpcRequest, opts ...grpc.CallOption) (*HandleRpcResponse, error)
+}
+
+type pluginV1Client struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewPluginV1Client wraps cc (typically the *grpc.ClientConn go-plugin hands
+// back from Dispense) as a PluginV1Client.
+func NewPluginV1Client(cc grpc.ClientConnInterface) PluginV1Client {
+	return &pluginV1Client{cc: cc}
+}
+
+func (c *pluginV1Client) ListTools(ctx context.Context, in *ListToolsRequest, opts ...grpc.CallOption) (*ListToolsResponse, error) {
+	out := new(ListToolsResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/ListTools", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginV1Client) CallTool(ctx context.Context, in *CallToolRequest, opts ...grpc.CallOption) (*CallToolResponse, error) {
+	out := new(CallToolResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/CallTool", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginV1Client) ListResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (*ListResourcesResponse, error) {
+	out := new(ListResourcesResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/ListResources", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginV1Client) ReadResource(ctx context.Context, in *ReadResourceRequest, opts ...grpc.CallOption) (*ReadResourceResponse, error) {
+	out := new(ReadResourceResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/ReadResource", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginV1Client) HandleRpc(ctx context.Context, in *HandleRpcRequest, opts ...grpc.CallOption) (*HandleRpcResponse, error) {
+	out := new(HandleRpcResponse)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/HandleRpc", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RegisterPluginV1Server registers srv as the PluginV1 implementation on s.
+func RegisterPluginV1Server(s grpc.ServiceRegistrar, srv PluginV1Server) {
+	s.RegisterService(&pluginV1ServiceDesc, srv)
+}
+
+func _PluginV1_ListTools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListToolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginV1Server).ListTools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/ListTools"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginV1Server).ListTools(ctx, req.(*ListToolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginV1_CallTool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallToolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginV1Server).CallTool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/CallTool"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginV1Server).CallTool(ctx, req.(*CallToolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginV1_ListResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginV1Server).ListResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/ListResources"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginV1Server).ListResources(ctx, req.(*ListResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginV1_ReadResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginV1Server).ReadResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/ReadResource"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginV1Server).ReadResource(ctx, req.(*ReadResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginV1_HandleRpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleRpcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginV1Server).HandleRpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/HandleRpc"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginV1Server).HandleRpc(ctx, req.(*HandleRpcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var pluginV1ServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*PluginV1Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ListTools", Handler: _PluginV1_ListTools_Handler},
+		{MethodName: "CallTool", Handler: _PluginV1_CallTool_Handler},
+		{MethodName: "ListResources", Handler: _PluginV1_ListResources_Handler},
+		{MethodName: "ReadResource", Handler: _PluginV1_ReadResource_Handler},
+		{MethodName: "HandleRpc", Handler: _PluginV1_HandleRpc_Handler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugin.proto",
+}
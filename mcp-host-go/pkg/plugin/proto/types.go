@@ -0,0 +1,75 @@
+// Package proto defines the nanobrowser.PluginV1 gRPC contract described in
+// plugin.proto. These types are hand-maintained rather than protoc-generated
+// (this environment has no protoc/protoc-gen-go-grpc installed); they're
+// carried over the wire via the JSON codec registered in codec.go instead of
+// real protobuf binary encoding. Regenerate properly from plugin.proto with
+// protoc-gen-go/protoc-gen-go-grpc once that tooling is available, keeping
+// the field names below in sync with the .proto message definitions.
+package proto
+
+// Tool mirrors the Tool message in plugin.proto.
+type Tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+type ListToolsRequest struct{}
+
+type ListToolsResponse struct {
+	Tools []Tool `json:"tools"`
+}
+
+type CallToolRequest struct {
+	Name string                 `json:"name"`
+	Args map[string]interface{} `json:"args"`
+}
+
+type ToolResultItem struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type CallToolResponse struct {
+	Content []ToolResultItem `json:"content"`
+	Error   string           `json:"error,omitempty"`
+}
+
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	MimeType    string `json:"mimeType"`
+	Description string `json:"description"`
+}
+
+type ListResourcesRequest struct{}
+
+type ListResourcesResponse struct {
+	Resources []Resource `json:"resources"`
+}
+
+type ReadResourceRequest struct {
+	URI       string                 `json:"uri"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+type ResourceItem struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type ReadResourceResponse struct {
+	Contents []ResourceItem `json:"contents"`
+	Error    string         `json:"error,omitempty"`
+}
+
+type HandleRpcRequest struct {
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+}
+
+type HandleRpcResponse struct {
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
@@ -0,0 +1,73 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/plugin/proto"
+)
+
+// grpcServer adapts an Implementation to proto.PluginV1Server, converting
+// between the SDK's plain value types and the wire message types.
+type grpcServer struct {
+	impl Implementation
+}
+
+func (s *grpcServer) ListTools(ctx context.Context, _ *proto.ListToolsRequest) (*proto.ListToolsResponse, error) {
+	tools, err := s.impl.ListTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]proto.Tool, 0, len(tools))
+	for _, t := range tools {
+		out = append(out, proto.Tool{Name: t.Name, Description: t.Description, InputSchema: t.InputSchema})
+	}
+	return &proto.ListToolsResponse{Tools: out}, nil
+}
+
+func (s *grpcServer) CallTool(ctx context.Context, req *proto.CallToolRequest) (*proto.CallToolResponse, error) {
+	content, err := s.impl.CallTool(ctx, req.Name, req.Args)
+	if err != nil {
+		return &proto.CallToolResponse{Error: err.Error()}, nil
+	}
+
+	out := make([]proto.ToolResultItem, 0, len(content))
+	for _, item := range content {
+		out = append(out, proto.ToolResultItem{Type: item.Type, Text: item.Text})
+	}
+	return &proto.CallToolResponse{Content: out}, nil
+}
+
+func (s *grpcServer) ListResources(ctx context.Context, _ *proto.ListResourcesRequest) (*proto.ListResourcesResponse, error) {
+	resources, err := s.impl.ListResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]proto.Resource, 0, len(resources))
+	for _, r := range resources {
+		out = append(out, proto.Resource{URI: r.URI, Name: r.Name, MimeType: r.MimeType, Description: r.Description})
+	}
+	return &proto.ListResourcesResponse{Resources: out}, nil
+}
+
+func (s *grpcServer) ReadResource(ctx context.Context, req *proto.ReadResourceRequest) (*proto.ReadResourceResponse, error) {
+	items, err := s.impl.ReadResource(ctx, req.URI, req.Arguments)
+	if err != nil {
+		return &proto.ReadResourceResponse{Error: err.Error()}, nil
+	}
+
+	out := make([]proto.ResourceItem, 0, len(items))
+	for _, item := range items {
+		out = append(out, proto.ResourceItem{URI: item.URI, MimeType: item.MimeType, Text: item.Text})
+	}
+	return &proto.ReadResourceResponse{Contents: out}, nil
+}
+
+func (s *grpcServer) HandleRpc(ctx context.Context, req *proto.HandleRpcRequest) (*proto.HandleRpcResponse, error) {
+	result, err := s.impl.HandleRpc(ctx, req.Method, req.Params)
+	if err != nil {
+		return &proto.HandleRpcResponse{Error: err.Error()}, nil
+	}
+	return &proto.HandleRpcResponse{Result: result}, nil
+}
@@ -0,0 +1,274 @@
+package stdio
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HostHandler answers an RPC call a plugin makes back into the host, e.g. to
+// invoke a host tool such as navigate_to. Returning an error sends it back
+// to the plugin as an RPC error.
+type HostHandler func(method string, params json.RawMessage) (interface{}, error)
+
+// Client manages one plugin subprocess over a length-prefixed JSON-RPC 2.0
+// stream on its stdin/stdout: a uint32 little-endian length prefix followed
+// by that many bytes of JSON, the same framing NativeMessagingManager uses
+// to talk to the browser extension.
+type Client struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+
+	hostHandler HostHandler
+
+	nextID    int64
+	pendingMu sync.Mutex
+	pending   map[int64]chan rpcMessage
+
+	manifestCh chan Manifest
+	writeMu    sync.Mutex
+	closeOnce  sync.Once
+	done       chan struct{}
+}
+
+// Start launches path as a plugin subprocess and begins reading its frames
+// in the background. It returns as soon as the process has started; call
+// WaitForManifest to block for its startup handshake.
+func Start(path string, hostHandler HostHandler) (*Client, error) {
+	cmd := exec.Command(path)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin stdout: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start plugin: %w", err)
+	}
+
+	c := &Client{
+		cmd:         cmd,
+		stdin:       stdin,
+		stdout:      stdout,
+		hostHandler: hostHandler,
+		pending:     make(map[int64]chan rpcMessage),
+		manifestCh:  make(chan Manifest, 1),
+		done:        make(chan struct{}),
+	}
+
+	go c.readLoop()
+	return c, nil
+}
+
+// Done returns a channel that closes once the plugin's stdout stream ends,
+// e.g. because the process crashed or exited. A manager can select on this
+// to detect the crash and restart the plugin.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// WaitForManifest blocks until the plugin sends its startup manifest
+// message or timeout elapses.
+func (c *Client) WaitForManifest(timeout time.Duration) (Manifest, error) {
+	select {
+	case manifest := <-c.manifestCh:
+		return manifest, nil
+	case <-time.After(timeout):
+		return Manifest{}, fmt.Errorf("timed out waiting for plugin manifest")
+	}
+}
+
+// Call sends method/params to the plugin and waits up to timeout for its
+// response, mirroring the timeout semantics of types.RpcOptions{Timeout}
+// used for host-to-extension RPCs elsewhere in this host.
+func (c *Client) Call(ctx context.Context, method string, params interface{}, timeout time.Duration) (json.RawMessage, error) {
+	id := atomic.AddInt64(&c.nextID, 1)
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode params: %w", err)
+	}
+
+	replyCh := make(chan rpcMessage, 1)
+	c.pendingMu.Lock()
+	c.pending[id] = replyCh
+	c.pendingMu.Unlock()
+
+	if err := c.writeFrame(rpcMessage{JSONRPC: "2.0", ID: &id, Method: method, Params: paramsBytes}); err != nil {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+		return nil, fmt.Errorf("failed to send request to plugin: %w", err)
+	}
+
+	select {
+	case reply := <-replyCh:
+		if reply.Error != nil {
+			return nil, reply.Error
+		}
+		return reply.Result, nil
+	case <-time.After(timeout):
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+		return nil, fmt.Errorf("plugin call %q timed out after %s", method, timeout)
+	case <-ctx.Done():
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// Ping is a lightweight health check a manager can poll periodically to
+// detect a hung (but not yet exited) plugin process.
+func (c *Client) Ping(ctx context.Context, timeout time.Duration) error {
+	_, err := c.Call(ctx, "ping", nil, timeout)
+	return err
+}
+
+// Close asks the plugin to exit gracefully by sending it SIGINT -- the same
+// signal TestProcessLifecycle sends the host itself -- and kills it if it
+// hasn't exited within gracePeriod.
+func (c *Client) Close(gracePeriod time.Duration) error {
+	var closeErr error
+	c.closeOnce.Do(func() {
+		if c.cmd.Process != nil {
+			_ = c.cmd.Process.Signal(os.Interrupt)
+		}
+
+		done := make(chan error, 1)
+		go func() { done <- c.cmd.Wait() }()
+
+		select {
+		case closeErr = <-done:
+		case <-time.After(gracePeriod):
+			if c.cmd.Process != nil {
+				_ = c.cmd.Process.Kill()
+			}
+			closeErr = <-done
+		}
+
+		_ = c.stdin.Close()
+	})
+	return closeErr
+}
+
+func (c *Client) readLoop() {
+	defer close(c.done)
+
+	reader := bufio.NewReader(c.stdout)
+	for {
+		var length uint32
+		if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+			c.failPending(fmt.Errorf("plugin stdout closed: %w", err))
+			return
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			c.failPending(fmt.Errorf("failed to read plugin frame: %w", err))
+			return
+		}
+
+		var msg rpcMessage
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			continue
+		}
+
+		c.handleFrame(msg)
+	}
+}
+
+func (c *Client) handleFrame(msg rpcMessage) {
+	switch {
+	case msg.Method == "manifest":
+		var manifest Manifest
+		if err := json.Unmarshal(msg.Params, &manifest); err == nil {
+			select {
+			case c.manifestCh <- manifest:
+			default:
+			}
+		}
+		if msg.ID != nil {
+			_ = c.writeFrame(rpcMessage{JSONRPC: "2.0", ID: msg.ID, Result: json.RawMessage("true")})
+		}
+	case msg.Method != "":
+		// A request or notification from the plugin calling back into the
+		// host; served concurrently so it doesn't block the read loop.
+		go c.serveHostCall(msg)
+	case msg.ID != nil:
+		c.pendingMu.Lock()
+		ch, ok := c.pending[*msg.ID]
+		delete(c.pending, *msg.ID)
+		c.pendingMu.Unlock()
+		if ok {
+			ch <- msg
+		}
+	}
+}
+
+func (c *Client) serveHostCall(msg rpcMessage) {
+	if c.hostHandler == nil {
+		if msg.ID != nil {
+			_ = c.writeFrame(rpcMessage{JSONRPC: "2.0", ID: msg.ID, Error: &rpcError{Code: 501, Message: "host does not support callbacks"}})
+		}
+		return
+	}
+
+	result, err := c.hostHandler(msg.Method, msg.Params)
+	if msg.ID == nil {
+		// Notification: the plugin isn't waiting on a reply.
+		return
+	}
+
+	if err != nil {
+		_ = c.writeFrame(rpcMessage{JSONRPC: "2.0", ID: msg.ID, Error: &rpcError{Code: 500, Message: err.Error()}})
+		return
+	}
+
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		_ = c.writeFrame(rpcMessage{JSONRPC: "2.0", ID: msg.ID, Error: &rpcError{Code: 500, Message: err.Error()}})
+		return
+	}
+	_ = c.writeFrame(rpcMessage{JSONRPC: "2.0", ID: msg.ID, Result: resultBytes})
+}
+
+func (c *Client) failPending(err error) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	for id, ch := range c.pending {
+		ch <- rpcMessage{Error: &rpcError{Code: 500, Message: err.Error()}}
+		delete(c.pending, id)
+	}
+}
+
+func (c *Client) writeFrame(msg rpcMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := binary.Write(c.stdin, binary.LittleEndian, uint32(len(payload))); err != nil {
+		return err
+	}
+	_, err = c.stdin.Write(payload)
+	return err
+}
@@ -0,0 +1,53 @@
+// Package stdio implements an out-of-process plugin transport that speaks
+// length-prefixed JSON-RPC 2.0 over a child process's stdin/stdout, mirroring
+// the framing the host already uses for Native Messaging with the browser
+// extension. Unlike pkg/plugin (which speaks gRPC via hashicorp/go-plugin),
+// a plugin here can issue its own RPC calls back into the host over the same
+// stream, letting it invoke whatever the host's HostHandler serves (in
+// pkg/mcp, the tools registered on the host, such as navigate_to).
+package stdio
+
+import "encoding/json"
+
+// Manifest is sent once by a plugin right after it starts, declaring every
+// tool and resource it provides.
+type Manifest struct {
+	Tools     []ToolManifest     `json:"tools"`
+	Resources []ResourceManifest `json:"resources"`
+}
+
+// ToolManifest describes one tool a plugin provides.
+type ToolManifest struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	InputSchema interface{} `json:"inputSchema"`
+}
+
+// ResourceManifest describes one resource a plugin provides.
+type ResourceManifest struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MimeType    string `json:"mimeType"`
+}
+
+// rpcMessage is the wire frame exchanged with a plugin in both directions:
+// a JSON-RPC 2.0 request (Method+ID set), notification (Method set, ID
+// nil), or response (Result or Error set).
+type rpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
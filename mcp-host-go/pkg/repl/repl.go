@@ -0,0 +1,336 @@
+// Package repl implements an interactive line-based shell for driving the
+// tools and resources exposed by an MCP host, without going through a full
+// MCP client. It is meant to be wired up two ways: inside the mcp-host
+// binary itself (routing straight through the in-process mcp.Server, via a
+// thin Router adapter), and inside integration tests via
+// env.McpHostTestEnvironment, which instead adapts the Native Messaging RPC
+// channel so a test can drive the exact same commands against a spawned
+// host process.
+package repl
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ToolInfo mirrors the shape returned by the host's list_tools RPC method.
+type ToolInfo struct {
+	Name        string
+	Description string
+	InputSchema interface{}
+}
+
+// ToolResultItem mirrors types.ToolResultItem without importing pkg/types,
+// so this package has no dependency on how a particular Router is wired up.
+type ToolResultItem struct {
+	Type string
+	Text string
+}
+
+// ToolResult mirrors types.ToolResult.
+type ToolResult struct {
+	Content []ToolResultItem
+}
+
+// Router is the minimal surface the REPL needs. Routing every command
+// through this interface, rather than maintaining a second copy of the tool
+// registry, is what keeps REPL behavior identical to the real execute_tool
+// and get_resource RPC paths.
+type Router interface {
+	ListTools() ([]ToolInfo, error)
+	ExecuteTool(name string, args map[string]interface{}) (ToolResult, error)
+	ReadResource(uri string) (interface{}, error)
+}
+
+// domStateURI is the resource dump_dom reads; it matches the URI registered
+// by resources.NewDomStateResource.
+const domStateURI = "browser://dom/state"
+
+// Repl is an interactive (or scripted) session bound to a Router.
+type Repl struct {
+	router  Router
+	out     io.Writer
+	history []string
+}
+
+// Config contains configuration for a Repl.
+type Config struct {
+	Router Router
+	Out    io.Writer
+}
+
+// New creates a new Repl.
+func New(config Config) (*Repl, error) {
+	if config.Router == nil {
+		return nil, fmt.Errorf("router is required")
+	}
+	if config.Out == nil {
+		return nil, fmt.Errorf("out is required")
+	}
+	return &Repl{router: config.Router, out: config.Out}, nil
+}
+
+// History returns the commands run so far, oldest first.
+func (r *Repl) History() []string {
+	return r.history
+}
+
+// Run reads commands from in, one per line, until "exit" or EOF, and
+// returns the process exit code the caller should use. Each executed
+// command's result (or "error: ..." line) is written to Repl's Out, and the
+// command is recorded in History; the command line itself is not echoed. A
+// blank line or a line starting with "#" is ignored.
+func (r *Repl) Run(in io.Reader) int {
+	scanner := bufio.NewScanner(in)
+	sawError := false
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		r.history = append(r.history, line)
+
+		stop, err := r.dispatch(line)
+		if err != nil {
+			fmt.Fprintf(r.out, "error: %s\n", err)
+			sawError = true
+		}
+		if stop {
+			break
+		}
+	}
+
+	if sawError {
+		return 1
+	}
+	return 0
+}
+
+// dispatch parses and runs a single command line. stop is true once the
+// session should end (the "exit" command).
+func (r *Repl) dispatch(line string) (stop bool, err error) {
+	tokens, err := tokenize(line)
+	if err != nil {
+		return false, err
+	}
+	if len(tokens) == 0 {
+		return false, nil
+	}
+
+	cmd, rest := tokens[0], tokens[1:]
+
+	switch cmd {
+	case "exit", "quit":
+		return true, nil
+
+	case "list_tools":
+		tools, err := r.router.ListTools()
+		if err != nil {
+			return false, err
+		}
+		sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+		for _, tool := range tools {
+			fmt.Fprintf(r.out, "%s - %s\n", tool.Name, tool.Description)
+		}
+		return false, nil
+
+	case "describe":
+		if len(rest) != 1 {
+			return false, fmt.Errorf("usage: describe <tool_name>")
+		}
+		tools, err := r.router.ListTools()
+		if err != nil {
+			return false, err
+		}
+		for _, tool := range tools {
+			if tool.Name == rest[0] {
+				schemaJSON, err := json.MarshalIndent(tool.InputSchema, "", "  ")
+				if err != nil {
+					return false, err
+				}
+				fmt.Fprintf(r.out, "%s - %s\n%s\n", tool.Name, tool.Description, schemaJSON)
+				return false, nil
+			}
+		}
+		return false, fmt.Errorf("unknown tool: %s", rest[0])
+
+	case "dump_dom":
+		content, err := r.router.ReadResource(domStateURI)
+		if err != nil {
+			return false, err
+		}
+		body, err := json.MarshalIndent(content, "", "  ")
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintln(r.out, string(body))
+		return false, nil
+
+	default:
+		return false, r.executeTool(cmd, rest)
+	}
+}
+
+// executeTool resolves cmd's input schema, maps the CLI tokens onto it, runs
+// the tool, and pretty-prints its ToolResult content.
+func (r *Repl) executeTool(cmd string, tokens []string) error {
+	tools, err := r.router.ListTools()
+	if err != nil {
+		return err
+	}
+
+	var schema interface{}
+	found := false
+	for _, tool := range tools {
+		if tool.Name == cmd {
+			schema = tool.InputSchema
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("unknown command: %s (try list_tools)", cmd)
+	}
+
+	args := argsFromTokens(schema, tokens)
+
+	result, err := r.router.ExecuteTool(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range result.Content {
+		fmt.Fprintln(r.out, item.Text)
+	}
+	return nil
+}
+
+// tokenize splits a command line on space characters, honoring double-quoted
+// substrings (so `set_value 3 "hello world" --submit` keeps "hello world"
+// together).
+func tokenize(line string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	inQuotes := false
+	hasToken := false
+
+	flush := func() {
+		if hasToken {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+			hasToken = false
+		}
+	}
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasToken = true
+		case r == ' ' && !inQuotes:
+			flush()
+		default:
+			cur.WriteRune(r)
+			hasToken = true
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	flush()
+
+	return tokens, nil
+}
+
+// argsFromTokens maps positional tokens onto schema's required properties in
+// order, and "--flag"/"--key=value" tokens onto matching properties (or,
+// failing that, into a nested "options" map, for tools like set_value whose
+// schema keeps secondary knobs under options).
+func argsFromTokens(schema interface{}, tokens []string) map[string]interface{} {
+	schemaMap, _ := schema.(map[string]interface{})
+	props, _ := schemaMap["properties"].(map[string]interface{})
+	required := stringSlice(schemaMap["required"])
+	_, hasOptionsProp := props["options"]
+
+	args := make(map[string]interface{})
+	var options map[string]interface{}
+	if hasOptionsProp {
+		options = make(map[string]interface{})
+	}
+
+	positionalIndex := 0
+	for _, token := range tokens {
+		if !strings.HasPrefix(token, "--") {
+			if positionalIndex < len(required) {
+				args[required[positionalIndex]] = coerce(token)
+				positionalIndex++
+			}
+			continue
+		}
+
+		flag := strings.TrimPrefix(token, "--")
+		key, value := flag, ""
+		if idx := strings.Index(flag, "="); idx >= 0 {
+			key, value = flag[:idx], flag[idx+1:]
+		}
+
+		coerced := interface{}(true)
+		if value != "" {
+			coerced = coerce(value)
+		}
+
+		if _, isTopLevel := props[key]; isTopLevel {
+			args[key] = coerced
+		} else if options != nil {
+			options[key] = coerced
+		} else {
+			args[key] = coerced
+		}
+	}
+
+	if options != nil && len(options) > 0 {
+		args["options"] = options
+	}
+
+	return args
+}
+
+// coerce converts a raw token into a float64, bool, or string, in that order
+// of preference, matching how JSON would decode the equivalent literal.
+// float64 is tried first so numeric tokens like "1" or "0" aren't mistaken
+// for strconv.ParseBool's accepted "1"/"0" spellings.
+func coerce(token string) interface{} {
+	if f, err := strconv.ParseFloat(token, 64); err == nil {
+		return f
+	}
+	if b, err := strconv.ParseBool(token); err == nil {
+		return b
+	}
+	return token
+}
+
+// stringSlice normalizes a schema's "required" field, which is []string
+// in-process and []interface{} once it has round-tripped through JSON.
+func stringSlice(v interface{}) []string {
+	switch vals := v.(type) {
+	case []string:
+		return vals
+	case []interface{}:
+		out := make([]string, 0, len(vals))
+		for _, val := range vals {
+			if s, ok := val.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,305 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+	"go.uber.org/zap"
+)
+
+// maxConsoleEvents bounds the ring buffer so a chatty page can't grow the
+// host's memory usage unbounded; oldest events are evicted first.
+const maxConsoleEvents = 2000
+
+// ConsoleEvent is one captured console.* call or uncaught JS exception.
+type ConsoleEvent struct {
+	API       string    `json:"api"` // "log", "warn", "error", "info", "debug", or "exception"
+	Args      []string  `json:"args"`
+	Stack     string    `json:"stack,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	URL       string    `json:"url"`
+	TabID     int       `json:"tabId"`
+}
+
+// ConsoleEventsResource implements the console log / JS exception capture
+// resource. Unlike DomStateResource, it doesn't poll the extension on every
+// read: the extension pushes each event to the host as it happens, and this
+// resource holds them in a bounded ring buffer for on-demand, filtered reads.
+type ConsoleEventsResource struct {
+	uri         string
+	name        string
+	mimeType    string
+	description string
+	logger      logger.Logger
+	messaging   types.Messaging
+
+	mu     sync.Mutex
+	events []ConsoleEvent // ring buffer, oldest first
+}
+
+// ConsoleEventsConfig contains configuration for ConsoleEventsResource
+type ConsoleEventsConfig struct {
+	Logger    logger.Logger
+	Messaging types.Messaging
+}
+
+// NewConsoleEventsResource creates a new ConsoleEventsResource and registers
+// it to receive console_event messages pushed by the extension's runtime
+// hook.
+func NewConsoleEventsResource(config ConsoleEventsConfig) (*ConsoleEventsResource, error) {
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+
+	if config.Messaging == nil {
+		return nil, fmt.Errorf("messaging is required")
+	}
+
+	r := &ConsoleEventsResource{
+		uri:      "browser://console/events",
+		name:     "Console Events",
+		mimeType: "application/json",
+		description: `Captured console.log/warn/error/info/debug calls and uncaught JS exceptions from the active tab, oldest first, bounded to the most recent events.
+
+Path Filters (composable, any order):
+• /level/<level>: Only events matching level (log|warn|error|info|debug|exception)
+• /since/<rfc3339>: Only events at or after the given RFC3339 timestamp
+• /tab/<id>: Only events from the given tabId
+• /page/<n>/size/<m>: Pagination (default: page 1, size 100, max size 1000)
+
+Examples:
+- browser://console/events/level/error
+- browser://console/events/since/2026-07-27T00:00:00Z
+- browser://console/events/tab/123/page/2/size/50`,
+		logger:    config.Logger,
+		messaging: config.Messaging,
+	}
+
+	config.Messaging.RegisterHandler("console_event", r.handleConsoleEvent)
+
+	return r, nil
+}
+
+// GetURI returns the resource URI
+func (r *ConsoleEventsResource) GetURI() string {
+	return r.uri
+}
+
+// GetName returns the resource name
+func (r *ConsoleEventsResource) GetName() string {
+	return r.name
+}
+
+// GetMimeType returns the resource MIME type
+func (r *ConsoleEventsResource) GetMimeType() string {
+	return r.mimeType
+}
+
+// GetDescription returns the resource description
+func (r *ConsoleEventsResource) GetDescription() string {
+	return r.description
+}
+
+// Read reads the captured console events (uses default pagination)
+func (r *ConsoleEventsResource) Read() (types.ResourceContent, error) {
+	return r.ReadWithArguments(r.uri, nil)
+}
+
+// consoleFilterParams holds the filters parsed from a console events URI.
+type consoleFilterParams struct {
+	Level    string
+	Since    time.Time
+	TabID    *int
+	Page     int
+	PageSize int
+}
+
+// ReadWithArguments reads the captured console events, filtered and paginated
+// according to uri's path segments.
+func (r *ConsoleEventsResource) ReadWithArguments(uri string, arguments map[string]any) (types.ResourceContent, error) {
+	params, err := r.parseFilterParams(uri)
+	if err != nil {
+		return types.ResourceContent{}, err
+	}
+
+	r.mu.Lock()
+	snapshot := make([]ConsoleEvent, len(r.events))
+	copy(snapshot, r.events)
+	r.mu.Unlock()
+
+	filtered := make([]ConsoleEvent, 0, len(snapshot))
+	for _, event := range snapshot {
+		if params.Level != "" && event.API != params.Level {
+			continue
+		}
+		if !params.Since.IsZero() && event.Timestamp.Before(params.Since) {
+			continue
+		}
+		if params.TabID != nil && event.TabID != *params.TabID {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+
+	totalEvents := len(filtered)
+	totalPages := calculateConsolePageCount(totalEvents, params.PageSize)
+
+	startIndex := (params.Page - 1) * params.PageSize
+	if startIndex > totalEvents {
+		startIndex = totalEvents
+	}
+	endIndex := startIndex + params.PageSize
+	if endIndex > totalEvents {
+		endIndex = totalEvents
+	}
+
+	pageEvents := filtered[startIndex:endIndex]
+
+	body := map[string]interface{}{
+		"events": pageEvents,
+		"pagination": map[string]interface{}{
+			"currentPage":     params.Page,
+			"pageSize":        params.PageSize,
+			"totalEvents":     totalEvents,
+			"totalPages":      totalPages,
+			"hasNextPage":     params.Page < totalPages,
+			"hasPreviousPage": params.Page > 1,
+		},
+	}
+
+	jsonBytes, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		return types.ResourceContent{}, fmt.Errorf("failed to marshal console events: %w", err)
+	}
+
+	r.logger.Debug("Read console events",
+		zap.Int("totalEvents", totalEvents),
+		zap.Int("pageEvents", len(pageEvents)),
+		zap.String("level", params.Level))
+
+	return types.ResourceContent{
+		Contents: []types.ResourceItem{
+			{
+				URI:      uri,
+				MimeType: r.mimeType,
+				Text:     string(jsonBytes),
+			},
+		},
+	}, nil
+}
+
+// parseFilterParams parses the /level/<x>/since/<rfc3339>/tab/<id>/page/<n>/size/<m>
+// path segments trailing r.uri, in any order and any subset.
+func (r *ConsoleEventsResource) parseFilterParams(uri string) (consoleFilterParams, error) {
+	params := consoleFilterParams{Page: 1, PageSize: 100}
+
+	rest := strings.TrimPrefix(uri, r.uri)
+	rest = strings.Trim(rest, "/")
+	if rest == "" {
+		return params, nil
+	}
+
+	segments := strings.Split(rest, "/")
+	if len(segments)%2 != 0 {
+		return consoleFilterParams{}, fmt.Errorf("malformed console events URI path: %s", uri)
+	}
+
+	for i := 0; i < len(segments); i += 2 {
+		key, value := segments[i], segments[i+1]
+		switch key {
+		case "level":
+			params.Level = value
+		case "since":
+			since, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return consoleFilterParams{}, fmt.Errorf("invalid since timestamp %q: %w", value, err)
+			}
+			params.Since = since
+		case "tab":
+			tabID, err := strconv.Atoi(value)
+			if err != nil {
+				return consoleFilterParams{}, fmt.Errorf("invalid tab id %q", value)
+			}
+			params.TabID = &tabID
+		case "page":
+			page, err := strconv.Atoi(value)
+			if err != nil || page < 1 {
+				return consoleFilterParams{}, fmt.Errorf("invalid page %q", value)
+			}
+			params.Page = page
+		case "size":
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 1 || size > 1000 {
+				return consoleFilterParams{}, fmt.Errorf("invalid size %q", value)
+			}
+			params.PageSize = size
+		default:
+			return consoleFilterParams{}, fmt.Errorf("unknown console events filter: %s", key)
+		}
+	}
+
+	return params, nil
+}
+
+// calculateConsolePageCount calculates total pages based on total events and page size
+func calculateConsolePageCount(totalEvents, pageSize int) int {
+	if pageSize <= 0 {
+		return 0
+	}
+	return (totalEvents + pageSize - 1) / pageSize
+}
+
+// handleConsoleEvent is registered as the console_event message handler; the
+// extension's runtime hook calls it once per console.* invocation or
+// uncaught exception.
+func (r *ConsoleEventsResource) handleConsoleEvent(data interface{}) error {
+	payload, ok := data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("console_event payload must be an object")
+	}
+
+	event := ConsoleEvent{Timestamp: time.Now().UTC()}
+
+	if api, ok := payload["api"].(string); ok {
+		event.API = api
+	}
+	if rawArgs, ok := payload["args"].([]interface{}); ok {
+		event.Args = make([]string, 0, len(rawArgs))
+		for _, arg := range rawArgs {
+			if s, ok := arg.(string); ok {
+				event.Args = append(event.Args, s)
+			} else {
+				event.Args = append(event.Args, fmt.Sprintf("%v", arg))
+			}
+		}
+	}
+	if stack, ok := payload["stack"].(string); ok {
+		event.Stack = stack
+	}
+	if url, ok := payload["url"].(string); ok {
+		event.URL = url
+	}
+	if tabID, ok := payload["tabId"].(float64); ok {
+		event.TabID = int(tabID)
+	}
+
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	if len(r.events) > maxConsoleEvents {
+		r.events = r.events[len(r.events)-maxConsoleEvents:]
+	}
+	r.mu.Unlock()
+
+	r.logger.Debug("Captured console event",
+		zap.String("api", event.API),
+		zap.String("url", event.URL),
+		zap.Int("tabId", event.TabID))
+
+	return nil
+}
@@ -17,12 +17,15 @@ type CurrentStateResource struct {
 	mimeType    string
 	description string
 	logger      logger.Logger
+	driver      types.BrowserDriver
 	messaging   types.Messaging
+	sub         resourceSubscription
 }
 
 // CurrentStateConfig contains configuration for CurrentStateResource
 type CurrentStateConfig struct {
 	Logger    logger.Logger
+	Driver    types.BrowserDriver
 	Messaging types.Messaging
 }
 
@@ -32,6 +35,10 @@ func NewCurrentStateResource(config CurrentStateConfig) (*CurrentStateResource,
 		return nil, fmt.Errorf("logger is required")
 	}
 
+	if config.Driver == nil {
+		return nil, fmt.Errorf("driver is required")
+	}
+
 	if config.Messaging == nil {
 		return nil, fmt.Errorf("messaging is required")
 	}
@@ -42,6 +49,7 @@ func NewCurrentStateResource(config CurrentStateConfig) (*CurrentStateResource,
 		mimeType:    "application/json",
 		description: "Complete state of the current active page and all tabs",
 		logger:      config.Logger,
+		driver:      config.Driver,
 		messaging:   config.Messaging,
 	}, nil
 }
@@ -70,8 +78,8 @@ func (r *CurrentStateResource) GetDescription() string {
 func (r *CurrentStateResource) Read() (types.ResourceContent, error) {
 	r.logger.Info("Reading current browser state")
 
-	// Request browser state from the extension
-	resp, err := r.messaging.RpcRequest(types.RpcRequest{
+	// Request browser state from the active driver (extension or headless fallback)
+	resp, err := r.driver.RpcRequest(types.RpcRequest{
 		Method: "get_browser_state",
 	}, types.RpcOptions{Timeout: 5000})
 
@@ -106,12 +114,28 @@ func (r *CurrentStateResource) Read() (types.ResourceContent, error) {
 	}, nil
 }
 
-// NotifyStateChange notifies that the state has changed
+// NotifyStateChange notifies that the state has changed. A client that has
+// subscribed to browser://current/state (via resources/subscribe) gets a
+// notifications/resources/updated message carrying an RFC 6902 JSON Patch
+// against the last snapshot it was sent, so it doesn't have to re-Read() the
+// full page state on every change. A client that hasn't subscribed still
+// gets the legacy resource_updated ping.
 func (r *CurrentStateResource) NotifyStateChange(state interface{}) {
 	r.logger.Debug("Notifying state change")
 
+	snapshot, err := json.Marshal(state)
+	if err != nil {
+		r.logger.Error("Error marshaling state for notification", zap.Error(err))
+		return
+	}
+
+	if params, ok := r.sub.update(r.uri, r.mimeType, snapshot); ok {
+		r.messaging.SendNotification("notifications/resources/updated", params)
+		return
+	}
+
 	// Send resource_updated message
-	err := r.messaging.SendMessage(types.Message{
+	err = r.messaging.SendMessage(types.Message{
 		Type: "resource_updated",
 		Data: map[string]interface{}{
 			"uri":       r.uri,
@@ -124,6 +148,17 @@ func (r *CurrentStateResource) NotifyStateChange(state interface{}) {
 	}
 }
 
+// Subscribe starts pushing incremental updates for this resource to
+// notifications/resources/updated subscribers.
+func (r *CurrentStateResource) Subscribe() error {
+	return r.sub.subscribe()
+}
+
+// Unsubscribe stops pushing incremental updates for this resource.
+func (r *CurrentStateResource) Unsubscribe() error {
+	return r.sub.unsubscribe()
+}
+
 // getCurrentTimestamp returns the current timestamp in milliseconds
 func getCurrentTimestamp() int64 {
 	return timeNow().UnixNano() / int64(1e6)
@@ -0,0 +1,412 @@
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+	"go.uber.org/zap"
+)
+
+// DomQueryResource implements a structured element-query resource over the
+// same DOM snapshot DomStateResource paginates, so an agent that knows what
+// it's looking for (a submit button, a visible input near element 12) can
+// ask for it directly instead of paging through hundreds of elements.
+type DomQueryResource struct {
+	uri         string
+	name        string
+	mimeType    string
+	description string
+	logger      logger.Logger
+	driver      types.BrowserDriver
+}
+
+// DomQueryConfig contains configuration for DomQueryResource
+type DomQueryConfig struct {
+	Logger logger.Logger
+	Driver types.BrowserDriver
+}
+
+// NewDomQueryResource creates a new DomQueryResource
+func NewDomQueryResource(config DomQueryConfig) (*DomQueryResource, error) {
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if config.Driver == nil {
+		return nil, fmt.Errorf("driver is required")
+	}
+
+	return &DomQueryResource{
+		uri:      "browser://dom/query",
+		name:     "DOM Query",
+		mimeType: "application/json",
+		description: `Query interactive elements of the current DOM snapshot with structured predicates, evaluated host-side against the same snapshot DomStateResource pages through.
+
+Arguments (all optional, combined with AND unless wrapped in all_of/any_of/not):
+• tag: tag name, e.g. "button" (case-insensitive)
+• role: ARIA role (case-insensitive)
+• text_regex: regular expression tested against the element's text
+• attr: map of attribute name -> exact value
+• visible: boolean, element is rendered and not hidden
+• in_viewport: boolean, element is within the current viewport
+• near_index: element index; matches are sorted by proximity to it
+• within_selector: substring the element's own stored selector must contain; DOM ancestry is not evaluated
+• all_of / any_of: arrays of nested predicate objects
+• not: a single nested predicate object to negate
+
+Response includes the matching elements and a snapshot_id fingerprint of
+the DOM state queried; pass that snapshot_id to set_value to get a
+StaleSnapshotError instead of a silently wrong element if the page has
+re-rendered since.`,
+		logger: config.Logger,
+		driver: config.Driver,
+	}, nil
+}
+
+// GetURI returns the resource URI
+func (r *DomQueryResource) GetURI() string {
+	return r.uri
+}
+
+// GetName returns the resource name
+func (r *DomQueryResource) GetName() string {
+	return r.name
+}
+
+// GetMimeType returns the resource MIME type
+func (r *DomQueryResource) GetMimeType() string {
+	return r.mimeType
+}
+
+// GetDescription returns the resource description
+func (r *DomQueryResource) GetDescription() string {
+	return r.description
+}
+
+// Read reads the query result for an empty predicate (matches everything)
+func (r *DomQueryResource) Read() (types.ResourceContent, error) {
+	return r.ReadWithArguments(r.uri, nil)
+}
+
+// ReadWithArguments evaluates the predicate in arguments against the current
+// DOM snapshot and returns the matching interactive elements.
+func (r *DomQueryResource) ReadWithArguments(uri string, arguments map[string]any) (types.ResourceContent, error) {
+	predicate, err := parseDomQueryPredicate(arguments)
+	if err != nil {
+		return types.ResourceContent{}, fmt.Errorf("invalid query predicate: %w", err)
+	}
+
+	resp, err := r.driver.RpcRequest(types.RpcRequest{
+		Method: "get_dom_state",
+	}, types.RpcOptions{Timeout: 5000})
+	if err != nil {
+		return types.ResourceContent{}, fmt.Errorf("failed to request DOM state: %w", err)
+	}
+	if resp.Error != nil {
+		return types.ResourceContent{}, fmt.Errorf("RPC error: %s", resp.Error.Message)
+	}
+
+	var domStateData DomStateData
+	jsonBytes, err := json.Marshal(resp.Result)
+	if err != nil {
+		return types.ResourceContent{}, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	if err := json.Unmarshal(jsonBytes, &domStateData); err != nil {
+		return types.ResourceContent{}, fmt.Errorf("failed to unmarshal DOM state data: %w", err)
+	}
+
+	matches := make([]map[string]interface{}, 0)
+	for _, element := range domStateData.InteractiveElements {
+		if evaluateDomQueryPredicate(predicate, element, domStateData.InteractiveElements) {
+			matches = append(matches, element)
+		}
+	}
+
+	if predicate.NearIndex != nil {
+		sortByProximity(matches, *predicate.NearIndex)
+	}
+
+	snapshotID := computeDomSnapshotID(domStateData.InteractiveElements)
+
+	body := map[string]interface{}{
+		"matches":     matches,
+		"matchCount":  len(matches),
+		"snapshot_id": snapshotID,
+	}
+
+	responseJSON, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		return types.ResourceContent{}, fmt.Errorf("failed to marshal query result: %w", err)
+	}
+
+	r.logger.Debug("Evaluated DOM query",
+		zap.Int("matches", len(matches)),
+		zap.String("snapshot_id", snapshotID))
+
+	return types.ResourceContent{
+		Contents: []types.ResourceItem{
+			{
+				URI:      uri,
+				MimeType: r.mimeType,
+				Text:     string(responseJSON),
+			},
+		},
+	}, nil
+}
+
+// domQueryPredicate is one parsed node of the query tree; leaf fields are
+// ANDed together, then combined with AllOf/AnyOf/Not.
+type domQueryPredicate struct {
+	Tag            string
+	Role           string
+	TextRegex      *regexp.Regexp
+	Attr           map[string]string
+	Visible        *bool
+	InViewport     *bool
+	NearIndex      *int
+	WithinSelector string
+
+	AllOf []domQueryPredicate
+	AnyOf []domQueryPredicate
+	Not   *domQueryPredicate
+}
+
+// parseDomQueryPredicate parses the top-level predicate from a resource
+// arguments map. A nil/empty arguments map is a predicate that matches every
+// element.
+func parseDomQueryPredicate(arguments map[string]any) (domQueryPredicate, error) {
+	if arguments == nil {
+		return domQueryPredicate{}, nil
+	}
+	return parsePredicateNode(arguments)
+}
+
+// parsePredicateNode parses one predicate object, recursing into any
+// all_of/any_of/not children.
+func parsePredicateNode(raw map[string]interface{}) (domQueryPredicate, error) {
+	var pred domQueryPredicate
+
+	if v, ok := raw["tag"].(string); ok {
+		pred.Tag = v
+	}
+	if v, ok := raw["role"].(string); ok {
+		pred.Role = v
+	}
+	if v, ok := raw["within_selector"].(string); ok {
+		pred.WithinSelector = v
+	}
+	if v, ok := raw["text_regex"].(string); ok && v != "" {
+		re, err := regexp.Compile(v)
+		if err != nil {
+			return domQueryPredicate{}, fmt.Errorf("invalid text_regex: %w", err)
+		}
+		pred.TextRegex = re
+	}
+	if v, ok := raw["visible"].(bool); ok {
+		pred.Visible = &v
+	}
+	if v, ok := raw["in_viewport"].(bool); ok {
+		pred.InViewport = &v
+	}
+	if v, exists := raw["near_index"]; exists {
+		index, err := toIntQueryValue(v)
+		if err != nil {
+			return domQueryPredicate{}, fmt.Errorf("near_index: %w", err)
+		}
+		pred.NearIndex = &index
+	}
+	if attrRaw, ok := raw["attr"].(map[string]interface{}); ok {
+		pred.Attr = make(map[string]string, len(attrRaw))
+		for key, value := range attrRaw {
+			pred.Attr[key] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	if allOfRaw, ok := raw["all_of"].([]interface{}); ok {
+		for i, item := range allOfRaw {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				return domQueryPredicate{}, fmt.Errorf("all_of[%d] must be an object", i)
+			}
+			child, err := parsePredicateNode(itemMap)
+			if err != nil {
+				return domQueryPredicate{}, fmt.Errorf("all_of[%d]: %w", i, err)
+			}
+			pred.AllOf = append(pred.AllOf, child)
+		}
+	}
+	if anyOfRaw, ok := raw["any_of"].([]interface{}); ok {
+		for i, item := range anyOfRaw {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				return domQueryPredicate{}, fmt.Errorf("any_of[%d] must be an object", i)
+			}
+			child, err := parsePredicateNode(itemMap)
+			if err != nil {
+				return domQueryPredicate{}, fmt.Errorf("any_of[%d]: %w", i, err)
+			}
+			pred.AnyOf = append(pred.AnyOf, child)
+		}
+	}
+	if notRaw, ok := raw["not"].(map[string]interface{}); ok {
+		child, err := parsePredicateNode(notRaw)
+		if err != nil {
+			return domQueryPredicate{}, fmt.Errorf("not: %w", err)
+		}
+		pred.Not = &child
+	}
+
+	return pred, nil
+}
+
+// toIntQueryValue accepts either a JSON number (float64) or a numeric string
+// for predicate fields like near_index.
+func toIntQueryValue(v interface{}) (int, error) {
+	switch val := v.(type) {
+	case float64:
+		return int(val), nil
+	case int:
+		return val, nil
+	case string:
+		parsed, err := strconv.Atoi(val)
+		if err != nil {
+			return 0, fmt.Errorf("must be an integer")
+		}
+		return parsed, nil
+	default:
+		return 0, fmt.Errorf("must be an integer")
+	}
+}
+
+// evaluateDomQueryPredicate evaluates pred against element. allElements is
+// passed through so leaf predicates that need the whole snapshot (none yet,
+// but within_selector matching could in the future) have it available.
+func evaluateDomQueryPredicate(pred domQueryPredicate, element map[string]interface{}, allElements []map[string]interface{}) bool {
+	if pred.Tag != "" && !strings.EqualFold(elementStringField(element, "tagName"), pred.Tag) {
+		return false
+	}
+	if pred.Role != "" && !strings.EqualFold(elementStringField(element, "role"), pred.Role) {
+		return false
+	}
+	if pred.WithinSelector != "" && !strings.Contains(elementStringField(element, "selector"), pred.WithinSelector) {
+		return false
+	}
+	if pred.TextRegex != nil && !pred.TextRegex.MatchString(elementStringField(element, "text")) {
+		return false
+	}
+	if pred.Visible != nil {
+		if visible, ok := element["visible"].(bool); !ok || visible != *pred.Visible {
+			return false
+		}
+	}
+	if pred.InViewport != nil {
+		if inViewport, ok := element["inViewport"].(bool); !ok || inViewport != *pred.InViewport {
+			return false
+		}
+	}
+	for key, value := range pred.Attr {
+		if elementStringField(element, key) != value {
+			return false
+		}
+	}
+
+	for _, child := range pred.AllOf {
+		if !evaluateDomQueryPredicate(child, element, allElements) {
+			return false
+		}
+	}
+	if len(pred.AnyOf) > 0 {
+		matched := false
+		for _, child := range pred.AnyOf {
+			if evaluateDomQueryPredicate(child, element, allElements) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if pred.Not != nil && evaluateDomQueryPredicate(*pred.Not, element, allElements) {
+		return false
+	}
+
+	return true
+}
+
+// elementStringField reads a string-shaped field off an element map,
+// returning "" for anything absent or of another type.
+func elementStringField(element map[string]interface{}, key string) string {
+	s, _ := element[key].(string)
+	return s
+}
+
+// sortByProximity sorts matches in place by absolute distance from
+// nearIndex, closest first.
+func sortByProximity(matches []map[string]interface{}, nearIndex int) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return distanceFromIndex(matches[i], nearIndex) < distanceFromIndex(matches[j], nearIndex)
+	})
+}
+
+func distanceFromIndex(element map[string]interface{}, nearIndex int) int {
+	index, ok := elementIntField(element, "index")
+	if !ok {
+		return int(^uint(0) >> 1) // sort unindexed elements last
+	}
+	d := index - nearIndex
+	if d < 0 {
+		d = -d
+	}
+	return d
+}
+
+func elementIntField(element map[string]interface{}, key string) (int, bool) {
+	switch v := element[key].(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// computeDomSnapshotID fingerprints the (index, tagName, text) triple of
+// every interactive element, sorted by index, into a short hex digest. Two
+// reads of an unchanged DOM produce the same snapshot_id; any index shift,
+// insertion, or removal changes it, which is exactly the churn set_value
+// needs to detect before trusting a previously-resolved index.
+func computeDomSnapshotID(elements []map[string]interface{}) string {
+	type fingerprintEntry struct {
+		index   int
+		tagName string
+		text    string
+	}
+
+	entries := make([]fingerprintEntry, 0, len(elements))
+	for _, element := range elements {
+		index, _ := elementIntField(element, "index")
+		entries = append(entries, fingerprintEntry{
+			index:   index,
+			tagName: elementStringField(element, "tagName"),
+			text:    elementStringField(element, "text"),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].index < entries[j].index })
+
+	var b strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&b, "%d|%s|%s\n", e.index, e.tagName, e.text)
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])[:16]
+}
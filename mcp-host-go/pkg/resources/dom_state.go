@@ -1,11 +1,15 @@
 package resources
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/cache/domcache"
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
 	"go.uber.org/zap"
@@ -18,12 +22,22 @@ type DomStateResource struct {
 	mimeType    string
 	description string
 	logger      logger.Logger
+	driver      types.BrowserDriver
 	messaging   types.Messaging
+	sub         resourceSubscription
+	cache       *domcache.Cache
 }
 
 // DomStateConfig contains configuration for DomStateResource
 type DomStateConfig struct {
-	Logger    logger.Logger
+	Logger logger.Logger
+
+	// Driver resolves the get_dom_state read (and everything paginated from
+	// it), the same role it plays for CurrentStateResource.
+	Driver types.BrowserDriver
+
+	// Messaging carries subscription push notifications, which only the
+	// browser extension can deliver.
 	Messaging types.Messaging
 }
 
@@ -33,6 +47,10 @@ func NewDomStateResource(config DomStateConfig) (*DomStateResource, error) {
 		return nil, fmt.Errorf("logger is required")
 	}
 
+	if config.Driver == nil {
+		return nil, fmt.Errorf("driver is required")
+	}
+
 	if config.Messaging == nil {
 		return nil, fmt.Errorf("messaging is required")
 	}
@@ -47,16 +65,34 @@ Query Parameters:
 • page: Page number for pagination (default: 1, min: 1)
 • pageSize: Elements per page (default: 100, max: 1000)
 • elementType: Filter by type - button|input|link|select|textarea (default: all)
+• selector: Case-insensitive substring match against an element's stored selector/xpath
+• textContains: Case-insensitive substring match against an element's text
+• hrefContains: Case-insensitive substring match against an element's href
+• visibleOnly: If true, only return elements whose stored visible flag is true
+• revision: Pin the read to a specific cached DOM revision; returns a staleRevision marker if it no longer matches the latest
+• ifNoneMatch: Skip re-rendering if this matches the ETag of the current revision/page/filter combination
+• cursor: Opaque token from a previous response's nextCursor/prevCursor; recommended over page/pageSize for sequential iteration, since it resumes after the last element actually seen instead of at a numeric offset, and so can't skip or duplicate elements if the DOM mutates mid-iteration. Returns a staleCursor marker if its revision or filter no longer matches
 
 Examples:
 - Default: Returns first 100 elements
 - ?page=2&pageSize=50: Second page with 50 elements
 - ?elementType=button: Only button elements
 - ?page=1&pageSize=20&elementType=input: First 20 input elements
-
-Response includes pagination metadata and filtered results in Markdown format.`,
+- ?cursor=<nextCursor from previous response>: Next page by stable element ID, not offset
+- ?selector=submit-btn&visibleOnly=true: Only visible elements whose selector/xpath contains "submit-btn"
+- ?textContains=checkout&hrefContains=/cart: Elements whose text mentions "checkout" and whose href mentions "/cart"
+
+Only the first read per DOM revision hits the extension; subsequent
+pagination/filtering against the same revision is served from an
+in-process cache. Every response carries an ETag covering the revision,
+page and filter; pass it back as ifNoneMatch on a later read of the same
+view to get a cheap not-modified response instead of the full Markdown
+body. Response includes pagination metadata and filtered results in
+Markdown format.`,
 		logger:    config.Logger,
+		driver:    config.Driver,
 		messaging: config.Messaging,
+		cache:     domcache.New(domcache.Config{}),
 	}, nil
 }
 
@@ -93,30 +129,54 @@ func (r *DomStateResource) ReadWithArguments(uri string, arguments map[string]an
 	params := r.parsePaginationParams(arguments)
 	r.logger.Debug("Parsed pagination params", zap.Any("params", params))
 
-	// Request DOM state from the extension
-	resp, err := r.messaging.RpcRequest(types.RpcRequest{
-		Method: "get_dom_state",
-	}, types.RpcOptions{Timeout: 5000})
-
-	if err != nil {
-		r.logger.Error("Error requesting DOM state", zap.Error(err))
-		return types.ResourceContent{}, fmt.Errorf("failed to request DOM state: %w", err)
-	}
-
-	if resp.Error != nil {
-		r.logger.Error("RPC error getting DOM state", zap.Any("respError", resp.Error))
-		return types.ResourceContent{}, fmt.Errorf("RPC error: %s", resp.Error.Message)
+	currentRevision := r.cache.CurrentRevision(r.uri)
+	if params.RevisionSet && currentRevision != 0 && params.Revision != currentRevision {
+		// The caller pinned a page to a revision that has since been
+		// invalidated by NotifyStateChange; tell them to restart instead
+		// of silently serving a different snapshot than the one they've
+		// been paging through.
+		return types.ResourceContent{
+			Contents: []types.ResourceItem{
+				{URI: uri, MimeType: r.mimeType, Text: r.convertStaleRevisionToMarkdown(params.Revision, currentRevision)},
+			},
+		}, nil
 	}
 
-	// Parse the raw DOM state data
 	var domStateData DomStateData
-	if err := r.parseResponseToStruct(resp.Result, &domStateData); err != nil {
-		r.logger.Error("Error parsing DOM state data", zap.Error(err))
-		return types.ResourceContent{}, fmt.Errorf("failed to parse DOM state data: %w", err)
+	revision, cacheHit := r.cache.Get(r.uri, params.RevisionSet, params.Revision, &domStateData)
+	if !cacheHit {
+		// Request DOM state from the extension; this is the only RPC per
+		// revision, no matter how many pages are read against it.
+		resp, err := r.driver.RpcRequest(types.RpcRequest{
+			Method: "get_dom_state",
+		}, types.RpcOptions{Timeout: 5000})
+
+		if err != nil {
+			r.logger.Error("Error requesting DOM state", zap.Error(err))
+			return types.ResourceContent{}, fmt.Errorf("failed to request DOM state: %w", err)
+		}
+
+		if resp.Error != nil {
+			r.logger.Error("RPC error getting DOM state", zap.Any("respError", resp.Error))
+			return types.ResourceContent{}, fmt.Errorf("RPC error: %s", resp.Error.Message)
+		}
+
+		// Parse the raw DOM state data
+		if err := r.parseResponseToStruct(resp.Result, &domStateData); err != nil {
+			r.logger.Error("Error parsing DOM state data", zap.Error(err))
+			return types.ResourceContent{}, fmt.Errorf("failed to parse DOM state data: %w", err)
+		}
+
+		if err := r.cache.Put(r.uri, domStateData); err != nil {
+			r.logger.Error("Error caching DOM state", zap.Error(err))
+		}
+		revision = r.cache.CurrentRevision(r.uri)
 	}
 
 	// Apply pagination and filtering
-	paginatedState := r.applyPaginationAndFiltering(domStateData, params)
+	paginatedState := r.applyPaginationAndFiltering(domStateData, params, revision)
+	paginatedState.Revision = revision
+	paginatedState.ETag = r.computeETag(revision, params)
 
 	// Convert to Markdown format
 	markdownContent := r.convertToMarkdown(paginatedState)
@@ -138,12 +198,30 @@ func (r *DomStateResource) ReadWithArguments(uri string, arguments map[string]an
 	}, nil
 }
 
-// NotifyStateChange notifies that the DOM state has changed
+// NotifyStateChange notifies that the DOM state has changed. A client
+// subscribed to browser://dom/state (via resources/subscribe) gets a
+// notifications/resources/updated message carrying an RFC 6902 JSON Patch
+// against the raw DOM state it was last sent, the same push behavior
+// CurrentStateResource.NotifyStateChange offers. A client that hasn't
+// subscribed still gets the legacy resource_updated ping.
 func (r *DomStateResource) NotifyStateChange(state interface{}) {
 	r.logger.Debug("Notifying DOM state change")
 
+	snapshot, err := json.Marshal(state)
+	if err != nil {
+		r.logger.Error("Error marshaling DOM state for notification", zap.Error(err))
+		return
+	}
+
+	r.cache.Invalidate(r.uri)
+
+	if params, ok := r.sub.update(r.uri, "application/json", snapshot); ok {
+		r.messaging.SendNotification("notifications/resources/updated", params)
+		return
+	}
+
 	// Send resource_updated message
-	err := r.messaging.SendMessage(types.Message{
+	err = r.messaging.SendMessage(types.Message{
 		Type: "resource_updated",
 		Data: map[string]interface{}{
 			"uri":       r.uri,
@@ -156,11 +234,66 @@ func (r *DomStateResource) NotifyStateChange(state interface{}) {
 	}
 }
 
+// Subscribe starts pushing incremental updates for this resource to
+// notifications/resources/updated subscribers.
+func (r *DomStateResource) Subscribe() error {
+	return r.sub.subscribe()
+}
+
+// Unsubscribe stops pushing incremental updates for this resource.
+func (r *DomStateResource) Unsubscribe() error {
+	return r.sub.unsubscribe()
+}
+
+// ETag reports a content fingerprint for the view arguments would produce,
+// without actually reading or rendering it. It satisfies
+// types.ETaggedResource so the SSE transport can short-circuit a read when
+// the caller already holds this exact revision/page/filter combination.
+func (r *DomStateResource) ETag(uri string, arguments map[string]any) (string, error) {
+	params := r.parsePaginationParams(arguments)
+
+	revision := r.cache.CurrentRevision(r.uri)
+	if params.RevisionSet {
+		revision = params.Revision
+	}
+
+	return r.computeETag(revision, params), nil
+}
+
+// computeETag fingerprints the combination of DOM revision, pagination and
+// filtering that determines a read's rendered output, so two reads that
+// would produce identical Markdown get identical ETags.
+func (r *DomStateResource) computeETag(revision int64, params PaginationParams) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%d|%d|%s", revision, params.Page, params.PageSize, domStateFilterHash(params))))
+	return hex.EncodeToString(sum[:])
+}
+
 // PaginationParams represents pagination parameters
 type PaginationParams struct {
-	Page        int    // Page number, starting from 1
+	Page        int    // Page number, starting from 1 (ignored when Cursor is set)
 	PageSize    int    // Number of elements per page
 	ElementType string // Element type filter (optional)
+
+	// Revision pins the read to a specific DOM revision; only meaningful
+	// when RevisionSet is true. Revision 0 is a legitimate, reachable
+	// revision (the state before anything has invalidated the cache), so
+	// RevisionSet — not Revision != 0 — is what distinguishes "no pin
+	// requested" from "pinned to revision 0".
+	Revision    int64
+	RevisionSet bool
+
+	Cursor string // Opaque cursor token from a previous nextCursor/prevCursor (optional)
+
+	// Selector, TextContains and HrefContains are substring filters against
+	// an element's stored selector/xpath, text, and href fields
+	// respectively (all optional, case-insensitive, combined with AND).
+	Selector     string
+	TextContains string
+	HrefContains string
+
+	// VisibleOnly, if true, drops elements whose stored "visible" field
+	// isn't true.
+	VisibleOnly bool
 }
 
 // DomStateData represents the raw DOM state data from Chrome extension
@@ -177,21 +310,34 @@ type PaginatedDomState struct {
 	Meta                interface{}              `json:"meta"`
 	Pagination          PaginationInfo           `json:"pagination"`
 	Filter              *FilterInfo              `json:"filter,omitempty"`
+	Revision            int64                    `json:"revision"`
+	ETag                string                   `json:"etag"`
 }
 
-// PaginationInfo contains pagination metadata
+// PaginationInfo contains pagination metadata. CurrentPage/TotalPages are
+// only meaningful for numeric pagination. NextCursor is set on any page that
+// has a next page, numeric or cursor-based; PrevCursor is only set for
+// cursor-based pagination.
 type PaginationInfo struct {
-	CurrentPage     int  `json:"currentPage"`
-	PageSize        int  `json:"pageSize"`
-	TotalElements   int  `json:"totalElements"`
-	TotalPages      int  `json:"totalPages"`
-	HasNextPage     bool `json:"hasNextPage"`
-	HasPreviousPage bool `json:"hasPreviousPage"`
+	CurrentPage     int    `json:"currentPage"`
+	PageSize        int    `json:"pageSize"`
+	TotalElements   int    `json:"totalElements"`
+	TotalPages      int    `json:"totalPages"`
+	HasNextPage     bool   `json:"hasNextPage"`
+	HasPreviousPage bool   `json:"hasPreviousPage"`
+	NextCursor      string `json:"nextCursor,omitempty"`
+	PrevCursor      string `json:"prevCursor,omitempty"`
+	StaleCursor     bool   `json:"staleCursor,omitempty"`
 }
 
-// FilterInfo contains filter metadata
+// FilterInfo contains filter metadata, echoing back every filter active on
+// a response so the Markdown header stays self-describing.
 type FilterInfo struct {
-	ElementType *string `json:"elementType,omitempty"`
+	ElementType  *string `json:"elementType,omitempty"`
+	Selector     *string `json:"selector,omitempty"`
+	TextContains *string `json:"textContains,omitempty"`
+	HrefContains *string `json:"hrefContains,omitempty"`
+	VisibleOnly  *bool   `json:"visibleOnly,omitempty"`
 }
 
 // parsePaginationParams parses pagination parameters from arguments map
@@ -234,6 +380,60 @@ func (r *DomStateResource) parsePaginationParams(arguments map[string]any) Pagin
 		}
 	}
 
+	// Parse revision parameter. 0 is a valid revision to pin to, so presence
+	// of a successfully-parsed non-negative value — not its value — is what
+	// sets RevisionSet.
+	if revisionVal, exists := arguments["revision"]; exists {
+		if revisionStr, ok := revisionVal.(string); ok {
+			if revision, err := strconv.ParseInt(revisionStr, 10, 64); err == nil && revision >= 0 {
+				params.Revision = revision
+				params.RevisionSet = true
+			}
+		} else if revisionFloat, ok := revisionVal.(float64); ok && revisionFloat >= 0 {
+			params.Revision = int64(revisionFloat)
+			params.RevisionSet = true
+		}
+	}
+
+	// Parse cursor parameter
+	if cursorVal, exists := arguments["cursor"]; exists {
+		if cursorStr, ok := cursorVal.(string); ok && cursorStr != "" {
+			params.Cursor = cursorStr
+		}
+	}
+
+	// Parse selector parameter
+	if selectorVal, exists := arguments["selector"]; exists {
+		if selectorStr, ok := selectorVal.(string); ok && selectorStr != "" {
+			params.Selector = selectorStr
+		}
+	}
+
+	// Parse textContains parameter
+	if textVal, exists := arguments["textContains"]; exists {
+		if textStr, ok := textVal.(string); ok && textStr != "" {
+			params.TextContains = textStr
+		}
+	}
+
+	// Parse hrefContains parameter
+	if hrefVal, exists := arguments["hrefContains"]; exists {
+		if hrefStr, ok := hrefVal.(string); ok && hrefStr != "" {
+			params.HrefContains = hrefStr
+		}
+	}
+
+	// Parse visibleOnly parameter
+	if visibleVal, exists := arguments["visibleOnly"]; exists {
+		if visibleBool, ok := visibleVal.(bool); ok {
+			params.VisibleOnly = visibleBool
+		} else if visibleStr, ok := visibleVal.(string); ok {
+			if parsed, err := strconv.ParseBool(visibleStr); err == nil {
+				params.VisibleOnly = parsed
+			}
+		}
+	}
+
 	return params
 }
 
@@ -252,26 +452,114 @@ func (r *DomStateResource) parseResponseToStruct(result interface{}, target inte
 	return nil
 }
 
-// applyPaginationAndFiltering applies pagination and filtering to DOM state data
-func (r *DomStateResource) applyPaginationAndFiltering(data DomStateData, params PaginationParams) PaginatedDomState {
+// applyPaginationAndFiltering applies filtering, then either cursor-based or
+// numeric pagination, to DOM state data.
+func (r *DomStateResource) applyPaginationAndFiltering(data DomStateData, params PaginationParams, revision int64) PaginatedDomState {
 	// Start with all interactive elements
 	elements := data.InteractiveElements
 
-	// Apply element type filtering if specified
+	// Apply element type, selector, text, href and visibility filtering if
+	// any are specified, composing them with AND.
 	var filterInfo *FilterInfo
-	if params.ElementType != "" {
+	if params.ElementType != "" || params.Selector != "" || params.TextContains != "" || params.HrefContains != "" || params.VisibleOnly {
 		filteredElements := make([]map[string]interface{}, 0)
 		for _, element := range elements {
-			if elementType, exists := element["type"]; exists {
-				if typeStr, ok := elementType.(string); ok && typeStr == params.ElementType {
-					filteredElements = append(filteredElements, element)
-				}
+			if elementMatchesFilters(element, params) {
+				filteredElements = append(filteredElements, element)
 			}
 		}
 		elements = filteredElements
-		filterInfo = &FilterInfo{ElementType: &params.ElementType}
+		filterInfo = &FilterInfo{}
+		if params.ElementType != "" {
+			filterInfo.ElementType = &params.ElementType
+		}
+		if params.Selector != "" {
+			filterInfo.Selector = &params.Selector
+		}
+		if params.TextContains != "" {
+			filterInfo.TextContains = &params.TextContains
+		}
+		if params.HrefContains != "" {
+			filterInfo.HrefContains = &params.HrefContains
+		}
+		if params.VisibleOnly {
+			filterInfo.VisibleOnly = &params.VisibleOnly
+		}
+	}
+
+	var paginatedElements []map[string]interface{}
+	var paginationInfo PaginationInfo
+	if params.Cursor != "" {
+		paginatedElements, paginationInfo = r.applyCursorPagination(elements, params, revision)
+	} else {
+		paginatedElements, paginationInfo = r.applyNumericPagination(elements, params)
+		if paginationInfo.HasNextPage && len(paginatedElements) > 0 {
+			// Lets a caller switch from a numeric read to cursor iteration.
+			paginationInfo.NextCursor = encodeDomStateCursor(domStateCursor{
+				Revision:     revision,
+				LastStableID: elementStableID(paginatedElements[len(paginatedElements)-1]),
+				FilterHash:   domStateFilterHash(params),
+			})
+		}
+	}
+
+	return PaginatedDomState{
+		FormattedDom:        data.FormattedDom,
+		InteractiveElements: paginatedElements,
+		Meta:                data.Meta,
+		Pagination:          paginationInfo,
+		Filter:              filterInfo,
+	}
+}
+
+// elementMatchesFilters reports whether element satisfies every active
+// filter in params, combined with AND. Selector/TextContains/HrefContains
+// are case-insensitive substring matches so callers don't need to know an
+// element's exact locator to find it.
+func elementMatchesFilters(element map[string]interface{}, params PaginationParams) bool {
+	if params.ElementType != "" {
+		typeStr, ok := element["type"].(string)
+		if !ok || typeStr != params.ElementType {
+			return false
+		}
+	}
+
+	if params.Selector != "" {
+		selector := elementStringField(element, "selector")
+		xpath := elementStringField(element, "xpath")
+		if !strings.Contains(strings.ToLower(selector), strings.ToLower(params.Selector)) &&
+			!strings.Contains(strings.ToLower(xpath), strings.ToLower(params.Selector)) {
+			return false
+		}
+	}
+
+	if params.TextContains != "" {
+		text := elementStringField(element, "text")
+		if !strings.Contains(strings.ToLower(text), strings.ToLower(params.TextContains)) {
+			return false
+		}
+	}
+
+	if params.HrefContains != "" {
+		href := elementStringField(element, "href")
+		if !strings.Contains(strings.ToLower(href), strings.ToLower(params.HrefContains)) {
+			return false
+		}
 	}
 
+	if params.VisibleOnly {
+		visible, ok := element["visible"].(bool)
+		if !ok || !visible {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyNumericPagination implements the original page/pageSize scheme, kept
+// for backward compatibility with existing callers.
+func (r *DomStateResource) applyNumericPagination(elements []map[string]interface{}, params PaginationParams) ([]map[string]interface{}, PaginationInfo) {
 	totalElements := len(elements)
 	totalPages := r.calculateTotalPages(totalElements, params.PageSize)
 
@@ -300,8 +588,7 @@ func (r *DomStateResource) applyPaginationAndFiltering(data DomStateData, params
 		paginatedElements = make([]map[string]interface{}, 0)
 	}
 
-	// Build pagination info
-	paginationInfo := PaginationInfo{
+	return paginatedElements, PaginationInfo{
 		CurrentPage:     params.Page,
 		PageSize:        params.PageSize,
 		TotalElements:   totalElements,
@@ -309,14 +596,163 @@ func (r *DomStateResource) applyPaginationAndFiltering(data DomStateData, params
 		HasNextPage:     params.Page < totalPages,
 		HasPreviousPage: params.Page > 1,
 	}
+}
 
-	return PaginatedDomState{
-		FormattedDom:        data.FormattedDom,
-		InteractiveElements: paginatedElements,
-		Meta:                data.Meta,
-		Pagination:          paginationInfo,
-		Filter:              filterInfo,
+// applyCursorPagination resolves params.Cursor by scanning for the element
+// whose stable ID matches the cursor's lastStableID and returning up to
+// PageSize elements after it, rather than slicing by numeric offset. This
+// is what avoids the classic "shifted results" problem: with page numbers,
+// elements can be skipped or duplicated across pages if the list length
+// changes between calls, since an offset means something different once
+// the DOM has mutated.
+func (r *DomStateResource) applyCursorPagination(elements []map[string]interface{}, params PaginationParams, revision int64) ([]map[string]interface{}, PaginationInfo) {
+	filterHash := domStateFilterHash(params)
+
+	cursor, err := decodeDomStateCursor(params.Cursor)
+	if err != nil || cursor.Revision != revision || cursor.FilterHash != filterHash {
+		return []map[string]interface{}{}, PaginationInfo{
+			PageSize:      params.PageSize,
+			TotalElements: len(elements),
+			StaleCursor:   true,
+		}
+	}
+
+	startIndex := 0
+	if cursor.LastStableID != "" {
+		found := false
+		for i, element := range elements {
+			if elementStableID(element) == cursor.LastStableID {
+				startIndex = i + 1
+				found = true
+				break
+			}
+		}
+		if !found {
+			// The element the cursor resumes after is no longer in the
+			// list (e.g. removed mid-iteration); surface the same signal
+			// as a revision mismatch rather than silently restarting.
+			return []map[string]interface{}{}, PaginationInfo{
+				PageSize:      params.PageSize,
+				TotalElements: len(elements),
+				StaleCursor:   true,
+			}
+		}
+	}
+
+	endIndex := startIndex + params.PageSize
+	if endIndex > len(elements) {
+		endIndex = len(elements)
+	}
+
+	var page []map[string]interface{}
+	if startIndex < endIndex {
+		page = elements[startIndex:endIndex]
+	} else {
+		page = make([]map[string]interface{}, 0)
+	}
+
+	info := PaginationInfo{
+		PageSize:        params.PageSize,
+		TotalElements:   len(elements),
+		HasNextPage:     endIndex < len(elements),
+		HasPreviousPage: startIndex > 0,
 	}
+
+	if info.HasNextPage {
+		info.NextCursor = encodeDomStateCursor(domStateCursor{
+			Revision:     revision,
+			LastStableID: elementStableID(page[len(page)-1]),
+			FilterHash:   filterHash,
+		})
+	}
+
+	if info.HasPreviousPage {
+		prevLastStableID := ""
+		if prevLastIndex := startIndex - params.PageSize - 1; prevLastIndex >= 0 {
+			prevLastStableID = elementStableID(elements[prevLastIndex])
+		}
+		info.PrevCursor = encodeDomStateCursor(domStateCursor{
+			Revision:     revision,
+			LastStableID: prevLastStableID,
+			FilterHash:   filterHash,
+		})
+	}
+
+	return page, info
+}
+
+// domStateCursor is the decoded form of an opaque pagination cursor. It
+// scopes a cursor to the exact DOM revision and filter it was issued
+// against, so a resumed cursor from a stale read is detected and rejected
+// rather than silently resolved against the wrong snapshot.
+type domStateCursor struct {
+	Revision     int64  `json:"revision"`
+	LastStableID string `json:"lastElementStableId"`
+	FilterHash   string `json:"filterHash"`
+}
+
+// encodeDomStateCursor serializes a cursor as base64-encoded JSON.
+func encodeDomStateCursor(c domStateCursor) string {
+	body, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(body)
+}
+
+// decodeDomStateCursor parses a cursor token produced by encodeDomStateCursor.
+func decodeDomStateCursor(token string) (domStateCursor, error) {
+	body, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return domStateCursor{}, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+	var c domStateCursor
+	if err := json.Unmarshal(body, &c); err != nil {
+		return domStateCursor{}, fmt.Errorf("invalid cursor payload: %w", err)
+	}
+	return c, nil
+}
+
+// domStateFilterHash fingerprints the full combination of filters in effect
+// so a cursor (or ETag) issued under one filter combination is rejected if
+// replayed under another.
+func domStateFilterHash(params PaginationParams) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s|%t", params.ElementType, params.Selector, params.TextContains, params.HrefContains, params.VisibleOnly)))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// elementStableID identifies an element independent of its position in the
+// list, preferring the most specific locator available so cursor resumption
+// survives elements shifting index between reads.
+func elementStableID(element map[string]interface{}) string {
+	if v := elementStringField(element, "selector"); v != "" {
+		return v
+	}
+	if v := elementStringField(element, "xpath"); v != "" {
+		return v
+	}
+	if v := elementStringField(element, "id"); v != "" {
+		return v
+	}
+	if index, ok := elementIntField(element, "index"); ok {
+		return fmt.Sprintf("idx:%d", index)
+	}
+	return ""
+}
+
+// convertStaleRevisionToMarkdown builds the response returned when a
+// caller's pinned revision no longer matches the cached DOM state,
+// instructing them to restart pagination instead of silently serving a
+// different snapshot than the one they've been paging through.
+func (r *DomStateResource) convertStaleRevisionToMarkdown(requested, current int64) string {
+	var builder strings.Builder
+	builder.WriteString("# DOM State\n\n")
+	builder.WriteString("## Stale Revision\n")
+	builder.WriteString("- **staleRevision:** true\n")
+	builder.WriteString(fmt.Sprintf("- **Requested Revision:** %d\n", requested))
+	builder.WriteString(fmt.Sprintf("- **Current Revision:** %d\n", current))
+	builder.WriteString("\nThe DOM has changed since this revision was read. Re-read `browser://dom/state` without a `revision` parameter to restart pagination against the current snapshot.\n")
+	return builder.String()
 }
 
 // calculateTotalPages calculates total pages based on total elements and page size
@@ -337,15 +773,44 @@ func (r *DomStateResource) convertToMarkdown(state PaginatedDomState) string {
 
 	// Pagination information
 	builder.WriteString("## Pagination\n")
-	builder.WriteString(fmt.Sprintf("- **Current Page:** %d of %d\n", state.Pagination.CurrentPage, state.Pagination.TotalPages))
+	builder.WriteString(fmt.Sprintf("- **Revision:** %d\n", state.Revision))
+	builder.WriteString(fmt.Sprintf("- **ETag:** %s\n", state.ETag))
+	if state.Pagination.StaleCursor {
+		builder.WriteString("- **Stale Cursor:** true\n")
+		builder.WriteString("\nThe cursor no longer matches the current DOM revision or filter. Re-read without `cursor` to restart iteration.\n")
+		return builder.String()
+	}
+	if state.Pagination.TotalPages > 0 {
+		builder.WriteString(fmt.Sprintf("- **Current Page:** %d of %d\n", state.Pagination.CurrentPage, state.Pagination.TotalPages))
+	}
 	builder.WriteString(fmt.Sprintf("- **Page Size:** %d elements\n", state.Pagination.PageSize))
 	builder.WriteString(fmt.Sprintf("- **Total Elements:** %d\n", state.Pagination.TotalElements))
 	builder.WriteString(fmt.Sprintf("- **Has Next Page:** %t\n", state.Pagination.HasNextPage))
 	builder.WriteString(fmt.Sprintf("- **Has Previous Page:** %t\n", state.Pagination.HasPreviousPage))
+	if state.Pagination.NextCursor != "" {
+		builder.WriteString(fmt.Sprintf("- **Next Cursor:** %s\n", state.Pagination.NextCursor))
+	}
+	if state.Pagination.PrevCursor != "" {
+		builder.WriteString(fmt.Sprintf("- **Prev Cursor:** %s\n", state.Pagination.PrevCursor))
+	}
 
 	// Filter information if applied
-	if state.Filter != nil && state.Filter.ElementType != nil {
-		builder.WriteString(fmt.Sprintf("- **Filtered by Element Type:** %s\n", *state.Filter.ElementType))
+	if state.Filter != nil {
+		if state.Filter.ElementType != nil {
+			builder.WriteString(fmt.Sprintf("- **Filtered by Element Type:** %s\n", *state.Filter.ElementType))
+		}
+		if state.Filter.Selector != nil {
+			builder.WriteString(fmt.Sprintf("- **Filtered by Selector:** %s\n", *state.Filter.Selector))
+		}
+		if state.Filter.TextContains != nil {
+			builder.WriteString(fmt.Sprintf("- **Filtered by Text Contains:** %s\n", *state.Filter.TextContains))
+		}
+		if state.Filter.HrefContains != nil {
+			builder.WriteString(fmt.Sprintf("- **Filtered by Href Contains:** %s\n", *state.Filter.HrefContains))
+		}
+		if state.Filter.VisibleOnly != nil {
+			builder.WriteString(fmt.Sprintf("- **Visible Only:** %t\n", *state.Filter.VisibleOnly))
+		}
 	}
 	builder.WriteString("\n")
 
@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/wI2L/jsondiff"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// resourceSubscription tracks per-resource subscription state so
+// NotifyStateChange can push an RFC 6902 JSON Patch of just what changed
+// instead of forcing every client to re-Read() the full resource. It is
+// meant to be embedded by a resource (e.g. CurrentStateResource) alongside
+// its own uri/mimeType fields.
+type resourceSubscription struct {
+	mu           sync.Mutex
+	subscribed   bool
+	lastSnapshot []byte
+}
+
+// subscribe marks the resource as subscribed; the next NotifyStateChange
+// always sends a full-content notification since there's no prior snapshot
+// yet to diff against.
+func (s *resourceSubscription) subscribe() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribed = true
+	s.lastSnapshot = nil
+	return nil
+}
+
+// unsubscribe stops pushing notifications for the resource.
+func (s *resourceSubscription) unsubscribe() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribed = false
+	s.lastSnapshot = nil
+	return nil
+}
+
+// update computes the notifications/resources/updated params to publish for
+// next, given the resource's current snapshot, and records next as the new
+// snapshot. ok is false when there's no active subscription, meaning the
+// caller should fall back to its own legacy notification instead.
+func (s *resourceSubscription) update(uri, mimeType string, next []byte) (params map[string]interface{}, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.subscribed {
+		return nil, false
+	}
+
+	params = map[string]interface{}{"uri": uri}
+
+	if s.lastSnapshot != nil {
+		if patch, err := jsondiff.CompareJSON(s.lastSnapshot, next); err == nil {
+			if patchBytes, err := json.Marshal(patch); err == nil && len(patchBytes) < len(next) {
+				params["patch"] = json.RawMessage(patchBytes)
+			}
+		}
+	}
+
+	// No usable patch, either because this is the first snapshot since
+	// subscribing or the patch would be larger than just sending the whole
+	// thing again.
+	if _, hasPatch := params["patch"]; !hasPatch {
+		params["contents"] = []types.ResourceItem{{URI: uri, MimeType: mimeType, Text: string(next)}}
+	}
+
+	s.lastSnapshot = next
+	return params, true
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/auth"
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
 )
@@ -20,8 +21,9 @@ type SSEServer struct {
 	messaging types.Messaging
 	hostInfo  types.HostInfo
 
-	mcpServer *server.MCPServer
-	sseServer *server.SSEServer
+	mcpServer  *server.MCPServer
+	sseServer  *server.SSEServer
+	httpServer *http.Server
 
 	// Keep track of registered tools and resources for adaptation
 	tools     map[string]types.Tool
@@ -32,6 +34,11 @@ type SSEServer struct {
 	port     string
 	baseURL  string
 	basePath string
+
+	// authVerifier, when set, gates every request behind a bearer token
+	// scoped to specific MCP operations. Nil means no auth is enforced,
+	// matching the server's historical behavior.
+	authVerifier *auth.Verifier
 }
 
 // SSEServerConfig contains configuration for the SSE Server
@@ -42,6 +49,10 @@ type SSEServerConfig struct {
 	Port      string // e.g., ":8080"
 	BaseURL   string // e.g., "http://localhost:8080"
 	BasePath  string // e.g., "/mcp"
+
+	// AuthVerifier, when set, requires every request to carry a bearer
+	// token verifiable by it, scoped to the MCP operation being invoked.
+	AuthVerifier *auth.Verifier
 }
 
 // NewSSEServer creates a new SSE-based MCP server
@@ -81,16 +92,17 @@ func NewSSEServer(config SSEServerConfig) (*SSEServer, error) {
 	)
 
 	s := &SSEServer{
-		logger:    config.Logger,
-		messaging: config.Messaging,
-		hostInfo:  config.HostInfo,
-		mcpServer: mcpServer,
-		sseServer: sseServer,
-		tools:     make(map[string]types.Tool),
-		resources: make(map[string]types.Resource),
-		port:      config.Port,
-		baseURL:   config.BaseURL,
-		basePath:  config.BasePath,
+		logger:       config.Logger,
+		messaging:    config.Messaging,
+		hostInfo:     config.HostInfo,
+		mcpServer:    mcpServer,
+		sseServer:    sseServer,
+		tools:        make(map[string]types.Tool),
+		resources:    make(map[string]types.Resource),
+		port:         config.Port,
+		baseURL:      config.BaseURL,
+		basePath:     config.BasePath,
+		authVerifier: config.AuthVerifier,
 	}
 
 	return s, nil
@@ -162,9 +174,20 @@ func (s *SSEServer) RegisterResource(resource types.Resource) error {
 func (s *SSEServer) Start() error {
 	s.logger.Info("Starting SSE MCP server", "port", s.port, "baseURL", s.baseURL)
 
-	// Start the SSE server
+	var handler http.Handler = s.sseServer
+	if s.authVerifier != nil {
+		handler = auth.Middleware(s.authVerifier, handler)
+	}
+
+	s.httpServer = &http.Server{
+		Addr:    s.port,
+		Handler: handler,
+	}
+
+	// Start the HTTP server ourselves (rather than delegating to
+	// sseServer.Start) so the auth middleware can wrap its handler.
 	go func() {
-		if err := s.sseServer.Start(s.port); err != nil && err != http.ErrServerClosed {
+		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			s.logger.Error("SSE server error", err)
 		}
 	}()
@@ -180,7 +203,15 @@ func (s *SSEServer) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	return s.sseServer.Shutdown(ctx)
+	// Close SSE sessions first, then the underlying HTTP server.
+	if err := s.sseServer.Shutdown(ctx); err != nil {
+		s.logger.Error("Error shutting down SSE sessions", err)
+	}
+
+	if s.httpServer == nil {
+		return nil
+	}
+	return s.httpServer.Shutdown(ctx)
 }
 
 // IsRunning returns true if the server is running
@@ -202,42 +233,14 @@ func (s *SSEServer) createMCPTool(tool types.Tool) mcp.Tool {
 	// Parse the schema and add parameters
 	if schemaMap, ok := schema.(map[string]interface{}); ok {
 		if properties, ok := schemaMap["properties"].(map[string]interface{}); ok {
+			required := requiredPropertyNames(schemaMap)
 			for propName, propDef := range properties {
-				if propDefMap, ok := propDef.(map[string]interface{}); ok {
-					if propType, ok := propDefMap["type"].(string); ok {
-						switch propType {
-						case "string":
-							stringOptions := []mcp.PropertyOption{}
-							if desc, ok := propDefMap["description"].(string); ok {
-								stringOptions = append(stringOptions, mcp.Description(desc))
-							}
-							// Check if it's required
-							if required, ok := schemaMap["required"].([]interface{}); ok {
-								for _, req := range required {
-									if reqStr, ok := req.(string); ok && reqStr == propName {
-										stringOptions = append(stringOptions, mcp.Required())
-										break
-									}
-								}
-							}
-							options = append(options, mcp.WithString(propName, stringOptions...))
-						case "number":
-							numberOptions := []mcp.PropertyOption{}
-							if desc, ok := propDefMap["description"].(string); ok {
-								numberOptions = append(numberOptions, mcp.Description(desc))
-							}
-							// Check if it's required
-							if required, ok := schemaMap["required"].([]interface{}); ok {
-								for _, req := range required {
-									if reqStr, ok := req.(string); ok && reqStr == propName {
-										numberOptions = append(numberOptions, mcp.Required())
-										break
-									}
-								}
-							}
-							options = append(options, mcp.WithNumber(propName, numberOptions...))
-						}
-					}
+				propDefMap, ok := propDef.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if opt := mcpToolOptionForProperty(propName, propDefMap, required[propName]); opt != nil {
+					options = append(options, opt)
 				}
 			}
 		}
@@ -246,6 +249,236 @@ func (s *SSEServer) createMCPTool(tool types.Tool) mcp.Tool {
 	return mcp.NewTool(tool.GetName(), options...)
 }
 
+// mcpToolOptionForProperty converts a single top-level JSON Schema property
+// into the mcp.ToolOption that adds it to the tool's input schema, or nil if
+// its type isn't one mcp-go has a WithX helper for.
+func mcpToolOptionForProperty(name string, propDefMap map[string]interface{}, required bool) mcp.ToolOption {
+	propType, _ := propDefMap["type"].(string)
+
+	// Object properties reuse the "required" JSON key for their own nested
+	// properties (see jsonSchemaPropertyOptions' "object" case), which
+	// collides with mcp.Required()'s use of that same key to flag the
+	// property itself as required to its parent. So for objects, mark the
+	// property required by appending to the tool's input schema directly
+	// rather than through mcp.Required().
+	markRequiredDirectly := required && propType == "object"
+	opts := jsonSchemaPropertyOptions(propDefMap, required && !markRequiredDirectly)
+
+	var base mcp.ToolOption
+	switch propType {
+	case "string":
+		base = mcp.WithString(name, opts...)
+	case "number":
+		base = mcp.WithNumber(name, opts...)
+	case "boolean":
+		base = mcp.WithBoolean(name, opts...)
+	case "object":
+		base = mcp.WithObject(name, opts...)
+	case "array":
+		base = mcp.WithArray(name, opts...)
+	default:
+		return nil
+	}
+
+	if markRequiredDirectly {
+		return func(t *mcp.Tool) {
+			base(t)
+			t.InputSchema.Required = append(t.InputSchema.Required, name)
+		}
+	}
+	return base
+}
+
+// jsonSchemaPropertyOptions translates the constraints on a single JSON
+// Schema property (description, required, enum, default, bounds, and for
+// object/array properties their nested schema) into mcp.PropertyOptions.
+// Nested object/array schemas are translated by convertNestedJSONSchema,
+// which recurses arbitrarily deep, so a tool's full schema survives the trip
+// through mcp-go instead of being flattened to just its top-level types.
+func jsonSchemaPropertyOptions(propDefMap map[string]interface{}, required bool) []mcp.PropertyOption {
+	opts := []mcp.PropertyOption{}
+
+	if desc, ok := propDefMap["description"].(string); ok {
+		opts = append(opts, mcp.Description(desc))
+	}
+	if required {
+		opts = append(opts, mcp.Required())
+	}
+
+	propType, _ := propDefMap["type"].(string)
+	switch propType {
+	case "string":
+		if def, ok := propDefMap["default"].(string); ok {
+			opts = append(opts, mcp.DefaultString(def))
+		}
+		if enumValues, ok := stringSliceValue(propDefMap["enum"]); ok {
+			opts = append(opts, mcp.Enum(enumValues...))
+		}
+		if minLen, ok := intValue(propDefMap["minLength"]); ok {
+			opts = append(opts, mcp.MinLength(minLen))
+		}
+		if maxLen, ok := intValue(propDefMap["maxLength"]); ok {
+			opts = append(opts, mcp.MaxLength(maxLen))
+		}
+		if pattern, ok := propDefMap["pattern"].(string); ok {
+			opts = append(opts, mcp.Pattern(pattern))
+		}
+	case "number":
+		if def, ok := floatValue(propDefMap["default"]); ok {
+			opts = append(opts, mcp.DefaultNumber(def))
+		}
+		if min, ok := floatValue(propDefMap["minimum"]); ok {
+			opts = append(opts, mcp.Min(min))
+		}
+		if max, ok := floatValue(propDefMap["maximum"]); ok {
+			opts = append(opts, mcp.Max(max))
+		}
+	case "boolean":
+		if def, ok := propDefMap["default"].(bool); ok {
+			opts = append(opts, mcp.DefaultBool(def))
+		}
+	case "object":
+		nestedProps := map[string]interface{}{}
+		if rawProps, ok := propDefMap["properties"].(map[string]interface{}); ok {
+			for nestedName, nestedDef := range rawProps {
+				if nestedDefMap, ok := nestedDef.(map[string]interface{}); ok {
+					nestedProps[nestedName] = convertNestedJSONSchema(nestedDefMap)
+				}
+			}
+		}
+		opts = append(opts, mcp.Properties(nestedProps))
+		if nestedRequired, ok := stringSliceValue(propDefMap["required"]); ok {
+			opts = append(opts, nestedRequiredOption(nestedRequired))
+		}
+	case "array":
+		if itemsDef, ok := propDefMap["items"].(map[string]interface{}); ok {
+			opts = append(opts, mcp.Items(convertNestedJSONSchema(itemsDef)))
+		}
+	}
+
+	return opts
+}
+
+// convertNestedJSONSchema recursively normalizes a nested JSON Schema (an
+// object property's "properties" entries, or an array property's "items")
+// into a plain map mcp-go can embed as-is, since mcp.Properties/mcp.Items
+// store whatever map they're given without interpreting it further. Unlike
+// jsonSchemaPropertyOptions, a property's "required" here stays the
+// object-level array JSON Schema itself uses, since there's no per-property
+// ToolOption plumbing to collapse it into at this depth.
+func convertNestedJSONSchema(propDefMap map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+
+	if propType, ok := propDefMap["type"].(string); ok {
+		result["type"] = propType
+	}
+	if desc, ok := propDefMap["description"].(string); ok {
+		result["description"] = desc
+	}
+	if def, ok := propDefMap["default"]; ok {
+		result["default"] = def
+	}
+	if enumValues, ok := stringSliceValue(propDefMap["enum"]); ok {
+		result["enum"] = enumValues
+	}
+	for _, key := range []string{"minimum", "maximum", "minLength", "maxLength", "pattern"} {
+		if v, ok := propDefMap[key]; ok {
+			result[key] = v
+		}
+	}
+
+	switch result["type"] {
+	case "object":
+		nestedProps := map[string]interface{}{}
+		if rawProps, ok := propDefMap["properties"].(map[string]interface{}); ok {
+			for nestedName, nestedDef := range rawProps {
+				if nestedDefMap, ok := nestedDef.(map[string]interface{}); ok {
+					nestedProps[nestedName] = convertNestedJSONSchema(nestedDefMap)
+				}
+			}
+		}
+		result["properties"] = nestedProps
+		if reqNames, ok := stringSliceValue(propDefMap["required"]); ok {
+			result["required"] = reqNames
+		}
+	case "array":
+		if itemsDef, ok := propDefMap["items"].(map[string]interface{}); ok {
+			result["items"] = convertNestedJSONSchema(itemsDef)
+		}
+	}
+
+	return result
+}
+
+// nestedRequiredOption sets an object property's own nested "required" list
+// (names of its own sub-properties) directly, since that's plain JSON
+// Schema data rather than the single-property required flag Required()
+// collapses into the parent's required array.
+func nestedRequiredOption(required []string) mcp.PropertyOption {
+	return func(schema map[string]any) {
+		schema["required"] = required
+	}
+}
+
+// requiredPropertyNames reads a schema's top-level "required" list,
+// accepting either []string (how this repo's tools usually write schema
+// literals) or []interface{} (how it comes back after a JSON round-trip).
+func requiredPropertyNames(schemaMap map[string]interface{}) map[string]bool {
+	names, _ := stringSliceValue(schemaMap["required"])
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// stringSliceValue converts a JSON Schema array-of-strings value (enum,
+// required) to []string, accepting either a literal []string or the
+// []interface{} shape a value has after a JSON round-trip.
+func stringSliceValue(v interface{}) ([]string, bool) {
+	switch vals := v.(type) {
+	case []string:
+		return vals, true
+	case []interface{}:
+		out := make([]string, 0, len(vals))
+		for _, item := range vals {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+// intValue converts a JSON Schema numeric value to int, accepting either a
+// literal int (how this repo's tools usually write schema literals) or the
+// float64 shape a value has after a JSON round-trip.
+func intValue(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
+
+// floatValue converts a JSON Schema numeric value to float64, accepting
+// int, float64, or the float64 shape a value has after a JSON round-trip.
+func floatValue(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
 // createToolHandler creates a handler function for mark3labs/mcp-go that calls our tool
 func (s *SSEServer) createToolHandler(tool types.Tool) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -287,13 +520,51 @@ func (s *SSEServer) createMCPResource(resource types.Resource) mcp.Resource {
 	)
 }
 
+// resourceWithArguments is implemented by resources whose rendered output
+// depends on the request's arguments (pagination, filters, revision pins)
+// rather than always returning a fixed default view.
+type resourceWithArguments interface {
+	ReadWithArguments(uri string, arguments map[string]any) (types.ResourceContent, error)
+}
+
+// notModifiedText is the body of the lightweight response returned when a
+// caller's ifNoneMatch argument matches the resource's current ETag.
+// mark3labs/mcp-go's ResourceContents has no header/metadata slot to carry
+// a real "304 Not Modified", so we signal it with an empty-for-the-body,
+// marker-for-the-type response instead of re-serializing the full content.
+const notModifiedText = `{"notModified":true}`
+
 // createResourceHandler creates a handler function for mark3labs/mcp-go that calls our resource
 func (s *SSEServer) createResourceHandler(resource types.Resource) func(context.Context, mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 		s.logger.Debug("Reading resource via SSE", "uri", resource.GetURI())
 
-		// Read our resource
-		content, err := resource.Read()
+		if etagResource, ok := resource.(types.ETaggedResource); ok {
+			etag, err := etagResource.ETag(request.Params.URI, request.Params.Arguments)
+			if err != nil {
+				return nil, err
+			}
+			if ifNoneMatch, _ := request.Params.Arguments["ifNoneMatch"].(string); ifNoneMatch != "" && ifNoneMatch == etag {
+				s.logger.Debug("Resource not modified, skipping render", "uri", resource.GetURI(), "etag", etag)
+				return []mcp.ResourceContents{
+					mcp.TextResourceContents{
+						URI:      request.Params.URI,
+						MIMEType: "application/json",
+						Text:     notModifiedText,
+					},
+				}, nil
+			}
+		}
+
+		// Read our resource, honoring request arguments (pagination, filters,
+		// revision pins) when the resource supports them.
+		var content types.ResourceContent
+		var err error
+		if argResource, ok := resource.(resourceWithArguments); ok {
+			content, err = argResource.ReadWithArguments(request.Params.URI, request.Params.Arguments)
+		} else {
+			content, err = resource.Read()
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,185 @@
+package sse
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestMcpToolOptionForProperty_String(t *testing.T) {
+	propDef := map[string]interface{}{
+		"type":        "string",
+		"description": "element selector",
+		"enum":        []string{"a", "b"},
+		"default":     "a",
+		"minLength":   1,
+		"maxLength":   10,
+		"pattern":     "^[a-z]+$",
+	}
+
+	tool := mcp.NewTool("test", mcpToolOptionForProperty("selector", propDef, true))
+
+	schema, ok := tool.InputSchema.Properties["selector"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected selector property in schema, got %#v", tool.InputSchema.Properties)
+	}
+	if schema["description"] != "element selector" {
+		t.Errorf("expected description to survive conversion, got %v", schema["description"])
+	}
+	if schema["default"] != "a" {
+		t.Errorf("expected default to survive conversion, got %v", schema["default"])
+	}
+	if schema["minLength"] != 1 {
+		t.Errorf("expected minLength to survive conversion, got %v", schema["minLength"])
+	}
+	if schema["maxLength"] != 10 {
+		t.Errorf("expected maxLength to survive conversion, got %v", schema["maxLength"])
+	}
+	if schema["pattern"] != "^[a-z]+$" {
+		t.Errorf("expected pattern to survive conversion, got %v", schema["pattern"])
+	}
+	if len(tool.InputSchema.Required) != 1 || tool.InputSchema.Required[0] != "selector" {
+		t.Errorf("expected selector to be marked required, got %v", tool.InputSchema.Required)
+	}
+}
+
+func TestMcpToolOptionForProperty_Number(t *testing.T) {
+	propDef := map[string]interface{}{
+		"type":    "number",
+		"default": float64(5),
+		"minimum": float64(1),
+		"maximum": float64(10),
+	}
+
+	tool := mcp.NewTool("test", mcpToolOptionForProperty("timeout", propDef, false))
+
+	schema, ok := tool.InputSchema.Properties["timeout"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected timeout property in schema, got %#v", tool.InputSchema.Properties)
+	}
+	if schema["default"] != float64(5) {
+		t.Errorf("expected default to survive conversion, got %v", schema["default"])
+	}
+	if schema["minimum"] != float64(1) {
+		t.Errorf("expected minimum to survive conversion, got %v", schema["minimum"])
+	}
+	if schema["maximum"] != float64(10) {
+		t.Errorf("expected maximum to survive conversion, got %v", schema["maximum"])
+	}
+	if len(tool.InputSchema.Required) != 0 {
+		t.Errorf("expected timeout not to be required, got %v", tool.InputSchema.Required)
+	}
+}
+
+func TestMcpToolOptionForProperty_Boolean(t *testing.T) {
+	propDef := map[string]interface{}{
+		"type":        "boolean",
+		"description": "run asynchronously",
+		"default":     true,
+	}
+
+	tool := mcp.NewTool("test", mcpToolOptionForProperty("async", propDef, false))
+
+	schema, ok := tool.InputSchema.Properties["async"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected async property in schema, got %#v", tool.InputSchema.Properties)
+	}
+	if schema["type"] != "boolean" {
+		t.Errorf("expected type boolean, got %v", schema["type"])
+	}
+	if schema["default"] != true {
+		t.Errorf("expected default to survive conversion, got %v", schema["default"])
+	}
+}
+
+func TestMcpToolOptionForProperty_ArrayOfObjects(t *testing.T) {
+	propDef := map[string]interface{}{
+		"type":        "array",
+		"description": "fields to fill",
+		"items": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name":  map[string]interface{}{"type": "string"},
+				"value": map[string]interface{}{"type": "string"},
+			},
+			"required": []string{"name"},
+		},
+	}
+
+	tool := mcp.NewTool("test", mcpToolOptionForProperty("fields", propDef, false))
+
+	schema, ok := tool.InputSchema.Properties["fields"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected fields property in schema, got %#v", tool.InputSchema.Properties)
+	}
+	items, ok := schema["items"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected items to be a nested schema, got %#v", schema["items"])
+	}
+	if items["type"] != "object" {
+		t.Errorf("expected nested items type object, got %v", items["type"])
+	}
+	nestedProps, ok := items["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected nested items properties, got %#v", items["properties"])
+	}
+	if _, ok := nestedProps["name"]; !ok {
+		t.Errorf("expected nested property 'name' to survive recursion, got %#v", nestedProps)
+	}
+	nestedRequired, ok := stringSliceValue(items["required"])
+	if !ok || len(nestedRequired) != 1 || nestedRequired[0] != "name" {
+		t.Errorf("expected nested required [name] to survive recursion, got %v", items["required"])
+	}
+}
+
+func TestMcpToolOptionForProperty_ObjectWithNestedRequired(t *testing.T) {
+	propDef := map[string]interface{}{
+		"type":        "object",
+		"description": "click coordinates",
+		"properties": map[string]interface{}{
+			"x": map[string]interface{}{"type": "number"},
+			"y": map[string]interface{}{"type": "number"},
+		},
+		"required": []string{"x", "y"},
+	}
+
+	tool := mcp.NewTool("test", mcpToolOptionForProperty("coordinates", propDef, true))
+
+	schema, ok := tool.InputSchema.Properties["coordinates"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected coordinates property in schema, got %#v", tool.InputSchema.Properties)
+	}
+	nestedProps, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected nested properties map, got %#v", schema["properties"])
+	}
+	if len(nestedProps) != 2 {
+		t.Errorf("expected 2 nested properties, got %d", len(nestedProps))
+	}
+	nestedRequired, ok := stringSliceValue(schema["required"])
+	if !ok || len(nestedRequired) != 2 {
+		t.Errorf("expected nested required [x y] to survive recursion, got %v", schema["required"])
+	}
+	if len(tool.InputSchema.Required) != 1 || tool.InputSchema.Required[0] != "coordinates" {
+		t.Errorf("expected coordinates itself to be marked required at the top level, got %v", tool.InputSchema.Required)
+	}
+}
+
+func TestMcpToolOptionForProperty_UnknownTypeReturnsNil(t *testing.T) {
+	propDef := map[string]interface{}{"type": "null"}
+	if opt := mcpToolOptionForProperty("weird", propDef, false); opt != nil {
+		t.Errorf("expected nil option for an unsupported type, got non-nil")
+	}
+}
+
+func TestRequiredPropertyNames_AcceptsLiteralAndRoundTrippedSlices(t *testing.T) {
+	literal := requiredPropertyNames(map[string]interface{}{"required": []string{"url"}})
+	if !literal["url"] {
+		t.Errorf("expected literal []string required list to be recognized, got %v", literal)
+	}
+
+	roundTripped := requiredPropertyNames(map[string]interface{}{"required": []interface{}{"url"}})
+	if !roundTripped["url"] {
+		t.Errorf("expected []interface{} required list to be recognized, got %v", roundTripped)
+	}
+}
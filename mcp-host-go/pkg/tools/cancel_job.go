@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/jobs"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// CancelJobTool implements a tool for cancelling a queued or running async
+// job.
+type CancelJobTool struct {
+	name        string
+	description string
+	logger      logger.Logger
+	jobs        *jobs.Manager
+}
+
+// CancelJobConfig contains configuration for CancelJobTool
+type CancelJobConfig struct {
+	Logger logger.Logger
+	Jobs   *jobs.Manager
+}
+
+// NewCancelJobTool creates a new CancelJobTool
+func NewCancelJobTool(config CancelJobConfig) (*CancelJobTool, error) {
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if config.Jobs == nil {
+		return nil, fmt.Errorf("jobs manager is required")
+	}
+
+	return &CancelJobTool{
+		name:        "cancel_job",
+		description: "Cancel a queued or running async job by ID",
+		logger:      config.Logger,
+		jobs:        config.Jobs,
+	}, nil
+}
+
+// GetName returns the tool name
+func (t *CancelJobTool) GetName() string { return t.name }
+
+// GetDescription returns the tool description
+func (t *CancelJobTool) GetDescription() string { return t.description }
+
+// GetInputSchema returns the tool input schema
+func (t *CancelJobTool) GetInputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"job_id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID returned when the job was queued",
+			},
+		},
+		"required":             []string{"job_id"},
+		"additionalProperties": false,
+	}
+}
+
+// Execute executes the cancel_job tool
+func (t *CancelJobTool) Execute(args map[string]interface{}) (types.ToolResult, error) {
+	jobID, ok := args["job_id"].(string)
+	if !ok || jobID == "" {
+		return types.ToolResult{}, fmt.Errorf("job_id is required and must be a string")
+	}
+
+	if err := t.jobs.Cancel(jobID); err != nil {
+		return types.ToolResult{}, err
+	}
+
+	return types.ToolResult{
+		Content: []types.ToolResultItem{
+			{Type: "text", Text: fmt.Sprintf("Job %s cancelled", jobID)},
+		},
+	}, nil
+}
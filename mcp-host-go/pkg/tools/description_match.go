@@ -0,0 +1,247 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Defaults for auto-selecting a single description match. A candidate is
+// auto-selected only when its score clears defaultMatchThreshold AND leads
+// the runner-up by at least defaultMatchMargin; otherwise the caller gets a
+// *MultipleMatchesError and should retry with target_type: "index".
+const (
+	defaultMatchThreshold = 0.55
+	defaultMatchMargin    = 0.25
+	maxMatchCandidates    = 5
+)
+
+// descriptionMatchFields are the element properties scored against a
+// target_type: "description" string, in the order the request asked for.
+var descriptionMatchFields = []string{"text", "placeholder", "aria-label", "name", "id", "title", "label-for"}
+
+// DescriptionMatch is one scored candidate element for a description target.
+type DescriptionMatch struct {
+	Index   int     `json:"index"`
+	Score   float64 `json:"score"`
+	TagName string  `json:"tagName"`
+	Text    string  `json:"text"`
+}
+
+// MultipleMatchesError is returned when no single candidate's score clearly
+// stands out; it lists the top candidates so the caller can retry with
+// target_type: "index" against one of them.
+type MultipleMatchesError struct {
+	Description string
+	Candidates  []DescriptionMatch
+}
+
+func (e *MultipleMatchesError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "ambiguous target description %q matched %d candidates; retry with target_type: \"index\":", e.Description, len(e.Candidates))
+	for _, c := range e.Candidates {
+		fmt.Fprintf(&b, "\n- index=%d score=%.2f tag=%s text=%q", c.Index, c.Score, c.TagName, c.Text)
+	}
+	return b.String()
+}
+
+// matchDescription scores every interactive element in data against
+// description and either auto-selects a single winning index or returns a
+// *MultipleMatchesError listing the top candidates.
+func matchDescription(data DomStateData, description string, value interface{}) (int, error) {
+	candidates := scoreElements(data.InteractiveElements, description, value)
+	if len(candidates) == 0 {
+		return 0, fmt.Errorf("no interactive elements matched description %q", description)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+
+	best := candidates[0]
+	if best.Score >= defaultMatchThreshold {
+		if len(candidates) == 1 || best.Score-candidates[1].Score >= defaultMatchMargin {
+			return best.Index, nil
+		}
+	}
+
+	topK := candidates
+	if len(topK) > maxMatchCandidates {
+		topK = topK[:maxMatchCandidates]
+	}
+	return 0, &MultipleMatchesError{Description: description, Candidates: topK}
+}
+
+// scoreElements scores every interactive element that has any textual
+// overlap with description at all; zero-score elements are dropped.
+func scoreElements(elements []map[string]interface{}, description string, value interface{}) []DescriptionMatch {
+	descTokens := tokenize(description)
+	descTrigrams := trigramSet(description)
+
+	matches := make([]DescriptionMatch, 0, len(elements))
+	for _, el := range elements {
+		score := scoreElement(el, descTokens, descTrigrams, value)
+		if score <= 0 {
+			continue
+		}
+
+		index, ok := intField(el, "index")
+		if !ok {
+			continue
+		}
+
+		matches = append(matches, DescriptionMatch{
+			Index:   index,
+			Score:   score,
+			TagName: stringField(el, "tagName"),
+			Text:    stringField(el, "text"),
+		})
+	}
+	return matches
+}
+
+// scoreElement combines normalized token overlap and trigram similarity
+// (taken as the best across descriptionMatchFields) with a small tag-type
+// prior favoring element kinds that suit value's shape.
+func scoreElement(el map[string]interface{}, descTokens map[string]bool, descTrigrams map[string]bool, value interface{}) float64 {
+	bestTokenOverlap := 0.0
+	bestTrigram := 0.0
+
+	for _, key := range descriptionMatchFields {
+		raw, ok := el[key].(string)
+		if !ok || strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		if overlap := jaccard(descTokens, tokenize(raw)); overlap > bestTokenOverlap {
+			bestTokenOverlap = overlap
+		}
+		if trigram := dice(descTrigrams, trigramSet(raw)); trigram > bestTrigram {
+			bestTrigram = trigram
+		}
+	}
+
+	if bestTokenOverlap == 0 && bestTrigram == 0 {
+		return 0
+	}
+
+	return 0.6*bestTokenOverlap + 0.4*bestTrigram + tagTypePrior(el, value)
+}
+
+// tagTypePrior nudges the score for element/value shape combinations that
+// are conventionally a good fit, e.g. a plain string value is more likely
+// destined for an <input> than a <select>.
+func tagTypePrior(el map[string]interface{}, value interface{}) float64 {
+	switch strings.ToLower(stringField(el, "tagName")) {
+	case "input", "textarea":
+		switch value.(type) {
+		case string, float64:
+			return 0.1
+		}
+	case "select":
+		if looksLikeEnumValue(value) {
+			return 0.1
+		}
+	}
+	return 0
+}
+
+// looksLikeEnumValue is a cheap heuristic for "this value looks like one of
+// a <select>'s option values" - a short, single-token string.
+func looksLikeEnumValue(value interface{}) bool {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return false
+	}
+	return !strings.ContainsAny(s, " \t\n") && len(s) <= 32
+}
+
+// tokenize lowercases s and splits it into a set of alphanumeric tokens.
+func tokenize(s string) map[string]bool {
+	tokens := make(map[string]bool)
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens[cur.String()] = true
+			cur.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			cur.WriteRune(r)
+		} else {
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// jaccard returns |a ∩ b| / |a ∪ b|, 0 if both sets are empty.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for token := range a {
+		if b[token] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	if union == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(union)
+}
+
+// trigramSet returns the set of lowercase, space-normalized character
+// trigrams of s (short strings still produce at least one trigram).
+func trigramSet(s string) map[string]bool {
+	normalized := " " + strings.Join(strings.Fields(strings.ToLower(s)), " ") + " "
+	trigrams := make(map[string]bool)
+
+	runes := []rune(normalized)
+	if len(runes) < 3 {
+		if len(runes) > 0 {
+			trigrams[string(runes)] = true
+		}
+		return trigrams
+	}
+	for i := 0; i+3 <= len(runes); i++ {
+		trigrams[string(runes[i:i+3])] = true
+	}
+	return trigrams
+}
+
+// dice returns the Sorensen-Dice coefficient 2|a ∩ b| / (|a|+|b|).
+func dice(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for trigram := range a {
+		if b[trigram] {
+			intersection++
+		}
+	}
+	return 2 * float64(intersection) / float64(len(a)+len(b))
+}
+
+// intField reads a numeric field (as decoded from JSON, so typically
+// float64) off an element map and returns it as an int.
+func intField(el map[string]interface{}, key string) (int, bool) {
+	switch v := el[key].(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// stringField reads a string field off an element map, returning "" if it's
+// absent or of another type.
+func stringField(el map[string]interface{}, key string) string {
+	s, _ := el[key].(string)
+	return s
+}
@@ -0,0 +1,91 @@
+package tools
+
+import "testing"
+
+func TestMatchDescription_AutoSelectsClearWinner(t *testing.T) {
+	data := DomStateData{
+		InteractiveElements: []map[string]interface{}{
+			{"index": float64(0), "tagName": "input", "placeholder": "Enter your email address"},
+			{"index": float64(1), "tagName": "input", "placeholder": "Enter your phone number"},
+		},
+	}
+
+	index, err := matchDescription(data, "phone number", "555-1234")
+	if err != nil {
+		t.Fatalf("expected a match, got error: %v", err)
+	}
+	if index != 1 {
+		t.Fatalf("expected index 1, got %d", index)
+	}
+}
+
+func TestMatchDescription_AmbiguousPlaceholdersReturnMultipleMatchesError(t *testing.T) {
+	data := DomStateData{
+		InteractiveElements: []map[string]interface{}{
+			{"index": float64(0), "tagName": "input", "placeholder": "Enter your name"},
+			{"index": float64(1), "tagName": "input", "placeholder": "Enter your full name"},
+		},
+	}
+
+	_, err := matchDescription(data, "Enter your name", "John")
+	if err == nil {
+		t.Fatalf("expected an ambiguous match error, got nil")
+	}
+
+	multiErr, ok := err.(*MultipleMatchesError)
+	if !ok {
+		t.Fatalf("expected *MultipleMatchesError, got %T: %v", err, err)
+	}
+	if len(multiErr.Candidates) < 2 {
+		t.Fatalf("expected at least 2 candidates, got %d", len(multiErr.Candidates))
+	}
+}
+
+func TestMatchDescription_NoMatchingElements(t *testing.T) {
+	data := DomStateData{
+		InteractiveElements: []map[string]interface{}{
+			{"index": float64(0), "tagName": "button", "text": "Submit"},
+		},
+	}
+
+	_, err := matchDescription(data, "email address", "a@b.com")
+	if err == nil {
+		t.Fatalf("expected an error when no element matches")
+	}
+	if _, ok := err.(*MultipleMatchesError); ok {
+		t.Fatalf("expected a plain no-match error, not *MultipleMatchesError")
+	}
+}
+
+func TestMatchDescription_TagTypePriorFavorsSelectForEnumLikeValue(t *testing.T) {
+	data := DomStateData{
+		InteractiveElements: []map[string]interface{}{
+			{"index": float64(0), "tagName": "input", "placeholder": "country code"},
+			{"index": float64(1), "tagName": "select", "label-for": "country code"},
+		},
+	}
+
+	index, err := matchDescription(data, "country code", "US")
+	if err != nil {
+		t.Fatalf("expected a match, got error: %v", err)
+	}
+	if index != 1 {
+		t.Fatalf("expected select element (index 1) to win via tag-type prior, got %d", index)
+	}
+}
+
+func TestTrigramSimilarity_IdenticalStringsScoreOne(t *testing.T) {
+	a := trigramSet("full name")
+	b := trigramSet("full name")
+	if dice(a, b) != 1 {
+		t.Fatalf("expected identical trigram sets to score 1, got %f", dice(a, b))
+	}
+}
+
+func TestTokenOverlap_JaccardOfDisjointSetsIsZero(t *testing.T) {
+	a := tokenize("email address")
+	b := tokenize("phone number")
+	if jaccard(a, b) != 0 {
+		t.Fatalf("expected disjoint token sets to score 0, got %f", jaccard(a, b))
+	}
+}
@@ -0,0 +1,30 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// fetchDomState requests the current DOM state directly over RPC, independent
+// of GetDomExtraElementsTool's pagination cache. Tools that need a single
+// point-in-time snapshot to resolve description targets (fill_form, set_value)
+// share this instead of each rolling their own RPC-and-decode logic.
+func fetchDomState(driver types.BrowserDriver) (DomStateData, error) {
+	resp, err := driver.RpcRequest(types.RpcRequest{
+		Method: "get_dom_state",
+	}, types.RpcOptions{Timeout: 5000})
+	if err != nil {
+		return DomStateData{}, fmt.Errorf("failed to request DOM state: %w", err)
+	}
+	if resp.Error != nil {
+		return DomStateData{}, fmt.Errorf("RPC error: %s", resp.Error.Message)
+	}
+
+	var domStateData DomStateData
+	if err := decodeViaJSON(resp.Result, &domStateData); err != nil {
+		return DomStateData{}, fmt.Errorf("failed to parse DOM state data: %w", err)
+	}
+
+	return domStateData, nil
+}
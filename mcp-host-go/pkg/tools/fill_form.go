@@ -0,0 +1,394 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+	"go.uber.org/zap"
+)
+
+// maxFillFormEntries caps a single fill_form call so a runaway entries array
+// can't balloon into an oversized batched RPC payload.
+const maxFillFormEntries = 50
+
+// FillFormTool implements the fill_form MCP tool: it batches several
+// set_value-shaped operations into a single round trip through Native
+// Messaging, resolving description targets against one shared DOM snapshot
+// up front so a field index shift triggered by an earlier step's typing
+// can't make a later step hit the wrong element.
+type FillFormTool struct {
+	name        string
+	description string
+	logger      logger.Logger
+	driver      types.BrowserDriver
+	messaging   types.Messaging
+}
+
+// FillFormConfig contains configuration for FillFormTool
+type FillFormConfig struct {
+	Logger logger.Logger
+
+	// Driver resolves description targets against the current DOM state
+	// (read-only), the same role it plays for NavigateToTool and
+	// CurrentStateResource.
+	Driver types.BrowserDriver
+
+	// Messaging carries the actual fill_form RPC, which only the browser
+	// extension can execute.
+	Messaging types.Messaging
+}
+
+// NewFillFormTool creates a new FillFormTool
+func NewFillFormTool(config FillFormConfig) (*FillFormTool, error) {
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+
+	if config.Driver == nil {
+		return nil, fmt.Errorf("driver is required")
+	}
+
+	if config.Messaging == nil {
+		return nil, fmt.Errorf("messaging is required")
+	}
+
+	return &FillFormTool{
+		name:        "fill_form",
+		description: "Fill multiple form fields in one batched operation, resolving all targets against a single DOM snapshot",
+		logger:      config.Logger,
+		driver:      config.Driver,
+		messaging:   config.Messaging,
+	}, nil
+}
+
+// GetName returns the tool name
+func (t *FillFormTool) GetName() string {
+	return t.name
+}
+
+// GetDescription returns the tool description
+func (t *FillFormTool) GetDescription() string {
+	return t.description
+}
+
+// GetInputSchema returns the tool input schema
+func (t *FillFormTool) GetInputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"entries": map[string]interface{}{
+				"type":        "array",
+				"description": fmt.Sprintf("Ordered list of set_value-style operations to perform in sequence (max %d)", maxFillFormEntries),
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"target": map[string]interface{}{
+							"oneOf": []map[string]interface{}{
+								{"type": "number", "description": "Element index from DOM state", "minimum": 0},
+								{"type": "string", "description": "Element description, label text, or identifier", "minLength": 1},
+							},
+							"description": "Target element (index or text description)",
+						},
+						"value": map[string]interface{}{
+							"description": "Value to set (string, number, boolean, or array for multi-select)",
+						},
+						"options": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"clear_first": map[string]interface{}{"type": "boolean", "default": true},
+								"submit":      map[string]interface{}{"type": "boolean", "default": false},
+								"wait_after":  map[string]interface{}{"type": "number", "minimum": 0, "maximum": 30, "default": 1},
+							},
+							"additionalProperties": false,
+						},
+					},
+					"required":             []string{"target", "value"},
+					"additionalProperties": false,
+				},
+				"minItems": 1,
+			},
+			"stop_on_error": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Abort the remaining entries as soon as one step fails",
+				"default":     true,
+			},
+			"submit_selector": map[string]interface{}{
+				"type":        "string",
+				"description": "CSS selector to click after all entries have been set, instead of relying on an individual entry's options.submit",
+			},
+			"revalidate_dom_between_steps": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Re-read DOM state before each step instead of resolving every description target once up front; slower, but safer against a page that re-renders between individual field sets",
+				"default":     false,
+			},
+			"retry": retryInputSchemaProperty(),
+		},
+		"required":             []string{"entries"},
+		"additionalProperties": false,
+	}
+}
+
+// fillFormEntry is one parsed, not-yet-resolved entry of the entries array.
+type fillFormEntry struct {
+	Target     interface{}
+	TargetType string
+	Value      interface{}
+	Options    map[string]interface{}
+}
+
+// fillFormStepResult is one entry of the RPC response's per-step results.
+type fillFormStepResult struct {
+	ElementType string      `json:"element_type"`
+	ActualValue interface{} `json:"actual_value"`
+	Success     bool        `json:"success"`
+	Error       string      `json:"error"`
+}
+
+// Execute executes the fill_form tool
+func (t *FillFormTool) Execute(args map[string]interface{}) (types.ToolResult, error) {
+	t.logger.Info("Executing fill_form tool", zap.Any("args", args))
+
+	entriesArg, exists := args["entries"]
+	if !exists {
+		return types.ToolResult{}, fmt.Errorf("entries is required")
+	}
+	rawEntries, ok := entriesArg.([]interface{})
+	if !ok || len(rawEntries) == 0 {
+		return types.ToolResult{}, fmt.Errorf("entries must be a non-empty array")
+	}
+	if len(rawEntries) > maxFillFormEntries {
+		return types.ToolResult{}, fmt.Errorf("entries exceeds the limit of %d", maxFillFormEntries)
+	}
+
+	stopOnError := true
+	if v, exists := args["stop_on_error"]; exists {
+		b, ok := v.(bool)
+		if !ok {
+			return types.ToolResult{}, fmt.Errorf("stop_on_error must be a boolean")
+		}
+		stopOnError = b
+	}
+
+	submitSelector, _ := args["submit_selector"].(string)
+
+	revalidateBetweenSteps := false
+	if v, exists := args["revalidate_dom_between_steps"]; exists {
+		b, ok := v.(bool)
+		if !ok {
+			return types.ToolResult{}, fmt.Errorf("revalidate_dom_between_steps must be a boolean")
+		}
+		revalidateBetweenSteps = b
+	}
+
+	retryCfg, err := parseRetryConfig(args)
+	if err != nil {
+		return types.ToolResult{}, err
+	}
+
+	entries := make([]fillFormEntry, 0, len(rawEntries))
+	for i, raw := range rawEntries {
+		entry, err := parseFillFormEntry(raw)
+		if err != nil {
+			return types.ToolResult{}, fmt.Errorf("entries[%d]: %w", i, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	// Resolve every description target against one shared DOM snapshot, so a
+	// re-render triggered by an earlier step can't shift a later step's
+	// numeric index out from under it.
+	if !revalidateBetweenSteps {
+		domState, err := fetchDomState(t.driver)
+		if err != nil {
+			return types.ToolResult{}, fmt.Errorf("failed to read DOM state for target resolution: %w", err)
+		}
+		for i := range entries {
+			if entries[i].TargetType != "description" {
+				continue
+			}
+			index, err := resolveDescriptionTarget(domState, entries[i].Target.(string))
+			if err != nil {
+				if stopOnError {
+					return types.ToolResult{}, fmt.Errorf("entries[%d]: %w", i, err)
+				}
+				continue
+			}
+			entries[i].Target = float64(index)
+			entries[i].TargetType = "index"
+		}
+	}
+
+	rpcEntries := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		rpcEntries = append(rpcEntries, map[string]interface{}{
+			"target":      entry.Target,
+			"target_type": entry.TargetType,
+			"value":       entry.Value,
+			"options":     entry.Options,
+		})
+	}
+
+	rpcParams := map[string]interface{}{
+		"entries":                      rpcEntries,
+		"stop_on_error":                stopOnError,
+		"submit_selector":              submitSelector,
+		"revalidate_dom_between_steps": revalidateBetweenSteps,
+	}
+
+	resp, err := withRetry(t.logger, t.name, retryCfg, func(attempt int) (types.RpcResponse, error) {
+		return t.messaging.RpcRequest(types.RpcRequest{
+			Method: "fill_form",
+			Params: rpcParams,
+		}, types.RpcOptions{Timeout: 30000})
+	}, nil)
+
+	if err != nil {
+		t.logger.Error("Error calling fill_form RPC", zap.Error(err))
+		return types.ToolResult{}, fmt.Errorf("fill_form RPC failed: %w", err)
+	}
+
+	if resp.Error != nil {
+		t.logger.Error("RPC error in fill_form", zap.Any("rpc_error", resp.Error))
+		return types.ToolResult{}, fmt.Errorf("RPC error: %s", resp.Error.Message)
+	}
+
+	var results []fillFormStepResult
+	if resultData, ok := resp.Result.(map[string]interface{}); ok {
+		if err := decodeViaJSON(resultData["results"], &results); err != nil {
+			return types.ToolResult{}, fmt.Errorf("failed to parse fill_form results: %w", err)
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Fill Form Result:\n")
+	failures := 0
+	for i, result := range results {
+		status := "OK"
+		if !result.Success {
+			status = "FAILED"
+			failures++
+		}
+		builder.WriteString(fmt.Sprintf("- Step %d [%s]: element_type=%s, actual_value=%v", i+1, status, result.ElementType, result.ActualValue))
+		if result.Error != "" {
+			builder.WriteString(fmt.Sprintf(", error=%s", result.Error))
+		}
+		builder.WriteString("\n")
+	}
+	builder.WriteString(fmt.Sprintf("Steps: %d, Failures: %d", len(results), failures))
+
+	t.logger.Info("fill_form completed", zap.Int("steps", len(results)), zap.Int("failures", failures))
+
+	if failures > 0 && stopOnError {
+		return types.ToolResult{}, fmt.Errorf("%s", builder.String())
+	}
+
+	return types.ToolResult{
+		Content: []types.ToolResultItem{
+			{Type: "text", Text: builder.String()},
+		},
+	}, nil
+}
+
+// parseFillFormEntry validates a single raw entries[i] value.
+func parseFillFormEntry(raw interface{}) (fillFormEntry, error) {
+	entryMap, ok := raw.(map[string]interface{})
+	if !ok {
+		return fillFormEntry{}, fmt.Errorf("must be an object")
+	}
+
+	targetArg, exists := entryMap["target"]
+	if !exists {
+		return fillFormEntry{}, fmt.Errorf("target is required")
+	}
+
+	valueArg, exists := entryMap["value"]
+	if !exists {
+		return fillFormEntry{}, fmt.Errorf("value is required")
+	}
+
+	var targetType string
+	switch target := targetArg.(type) {
+	case float64:
+		if target < 0 {
+			return fillFormEntry{}, fmt.Errorf("target index must be non-negative")
+		}
+		targetType = "index"
+	case string:
+		if len(target) == 0 {
+			return fillFormEntry{}, fmt.Errorf("target description cannot be empty")
+		}
+		targetType = "description"
+	default:
+		return fillFormEntry{}, fmt.Errorf("target must be a number (index) or string (description)")
+	}
+
+	options := map[string]interface{}{
+		"clear_first": true,
+		"submit":      false,
+		"wait_after":  1.0,
+	}
+	if optionsArg, exists := entryMap["options"]; exists {
+		optionsMap, ok := optionsArg.(map[string]interface{})
+		if !ok {
+			return fillFormEntry{}, fmt.Errorf("options must be an object")
+		}
+		for key, value := range optionsMap {
+			options[key] = value
+		}
+	}
+
+	return fillFormEntry{
+		Target:     targetArg,
+		TargetType: targetType,
+		Value:      valueArg,
+		Options:    options,
+	}, nil
+}
+
+// decodeViaJSON round-trips result through JSON into target, the same
+// approach GetDomExtraElementsTool.parseResponseToStruct uses to decode a
+// generic RPC result into a typed struct.
+func decodeViaJSON(result interface{}, target interface{}) error {
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+	if err := json.Unmarshal(jsonBytes, target); err != nil {
+		return fmt.Errorf("failed to unmarshal to target struct: %w", err)
+	}
+	return nil
+}
+
+// resolveDescriptionTarget finds the interactive element whose text,
+// placeholder, id, selector, or label contains description (case-insensitive), and
+// returns its index. This is a plain substring match; fuzzier matching is
+// intentionally left for a smarter target resolver to replace later.
+func resolveDescriptionTarget(data DomStateData, description string) (int, error) {
+	needle := strings.ToLower(description)
+
+	for _, element := range data.InteractiveElements {
+		for _, key := range []string{"text", "placeholder", "id", "selector", "label"} {
+			value, ok := element[key].(string)
+			if !ok {
+				continue
+			}
+			if strings.Contains(strings.ToLower(value), needle) {
+				index, ok := element["index"]
+				if !ok {
+					continue
+				}
+				switch v := index.(type) {
+				case float64:
+					return int(v), nil
+				case int:
+					return v, nil
+				}
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("no element matching description %q found in current DOM state", description)
+}
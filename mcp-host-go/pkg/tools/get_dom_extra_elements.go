@@ -1,24 +1,61 @@
 package tools
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// snapshotTTL is how long a cached DOM snapshot remains valid for cursor-based
+// pagination before it is considered expired.
+const snapshotTTL = 30 * time.Second
+
+// maxCachedSnapshots bounds the in-memory snapshot cache (a simple LRU by
+// creation time, evicted opportunistically on each Execute call rather than
+// by a background goroutine).
+const maxCachedSnapshots = 32
+
+// domSnapshot holds a DOM state capture pinned under a snapshotId so that
+// cursor-based pagination sees a stable view across calls.
+type domSnapshot struct {
+	data      DomStateData
+	createdAt time.Time
+}
+
+// pageTokenPayload is the (base64-encoded JSON) content of an opaque
+// nextPageToken. It is self-describing enough for the server to detect a
+// stale/expired snapshot and restart the list, mirroring how the Kubernetes
+// list pager handles "Expired" errors.
+type pageTokenPayload struct {
+	SnapshotID  string `json:"snapshotId"`
+	Offset      int    `json:"offset"`
+	Filter      string `json:"filter"`
+	PageSize    int    `json:"pageSize"`
+	GeneratedAt int64  `json:"generatedAt"`
+}
+
 // GetDomExtraElementsTool implements the get_dom_extra_elements MCP tool
 type GetDomExtraElementsTool struct {
-	logger    logger.Logger
-	messaging types.Messaging
+	logger logger.Logger
+	driver types.BrowserDriver
+
+	snapshotsMu sync.Mutex
+	snapshots   map[string]*domSnapshot
+
+	pageInfo pageInfoState
 }
 
 // GetDomExtraElementsConfig contains configuration for GetDomExtraElementsTool
 type GetDomExtraElementsConfig struct {
-	Logger    logger.Logger
-	Messaging types.Messaging
+	Logger logger.Logger
+	Driver types.BrowserDriver
 }
 
 // NewGetDomExtraElementsTool creates a new GetDomExtraElementsTool
@@ -27,13 +64,14 @@ func NewGetDomExtraElementsTool(config GetDomExtraElementsConfig) (*GetDomExtraE
 		return nil, fmt.Errorf("logger is required")
 	}
 
-	if config.Messaging == nil {
-		return nil, fmt.Errorf("messaging is required")
+	if config.Driver == nil {
+		return nil, fmt.Errorf("driver is required")
 	}
 
 	return &GetDomExtraElementsTool{
 		logger:    config.Logger,
-		messaging: config.Messaging,
+		driver:    config.Driver,
+		snapshots: make(map[string]*domSnapshot),
 	}, nil
 }
 
@@ -50,6 +88,8 @@ This tool provides access to all interactive elements on the page with advanced
 • Pagination: Navigate through pages of elements
 • Filtering: Filter by element type (button, input, link, select, textarea, all)
 • Flexible access: Get specific ranges or pages of elements
+• Cursor pagination: Pass the previous response's nextPageToken to keep reading from the same stable DOM snapshot even if the page has since changed (snapshots stay cached for 30 seconds)
+• Aggregation: Set aggregate=true to get every matching element merged into a single response instead of paging manually
 
 Use this tool when the DOM state overview (browser://dom/state) indicates there are more than 20 interactive elements available.`
 }
@@ -83,6 +123,20 @@ func (t *GetDomExtraElementsTool) GetInputSchema() interface{} {
 				"description": "Optional: Start from specific element index (1-based, overrides page parameter)",
 				"minimum":     1,
 			},
+			"pageToken": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional: Opaque cursor returned as nextPageToken by a previous call. Pages are read from the same DOM snapshot while it is cached (30 seconds); after that the list restarts from the first page of a fresh snapshot and the response sets snapshotExpired. Mutually exclusive with page/startIndex.",
+			},
+			"aggregate": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Return every matching element merged into a single response instead of one page. Mutually exclusive with page/startIndex/pageToken. Fails with an error if more than 1000 elements would be returned.",
+				"default":     false,
+			},
+			"dedupeByXPath": map[string]interface{}{
+				"type":        "boolean",
+				"description": "When aggregate=true, drop elements whose xpath/selector was already seen (useful for sticky elements like nav bars that appear on every page). Default: false (plain append).",
+				"default":     false,
+			},
 		},
 		"additionalProperties": false,
 	}
@@ -101,30 +155,85 @@ func (t *GetDomExtraElementsTool) Execute(arguments map[string]interface{}) (typ
 
 	t.logger.Debug("Parsed parameters", zap.Any("params", params))
 
-	// Request DOM state from the extension
-	resp, err := t.messaging.RpcRequest(types.RpcRequest{
-		Method: "get_dom_state",
-	}, types.RpcOptions{Timeout: 5000})
+	// Opportunistically evict expired/excess snapshots on every call instead
+	// of running a background goroutine.
+	t.evictSnapshots()
 
-	if err != nil {
-		t.logger.Error("Error requesting DOM state for extra elements", zap.Error(err))
-		return types.ToolResult{}, fmt.Errorf("failed to request DOM state: %w", err)
-	}
+	if params.Aggregate {
+		result, err := t.aggregateElements(params)
+		if err != nil {
+			t.logger.Error("Error aggregating DOM extra elements", zap.Error(err))
+			return types.ToolResult{}, err
+		}
 
-	if resp.Error != nil {
-		t.logger.Error("RPC error getting DOM state for extra elements", zap.Any("respError", resp.Error))
-		return types.ToolResult{}, fmt.Errorf("RPC error: %s", resp.Error.Message)
+		resultJSON, err := json.Marshal(result)
+		if err != nil {
+			t.logger.Error("Error marshaling aggregated result to JSON", zap.Error(err))
+			return types.ToolResult{}, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return types.ToolResult{
+			Content: []types.ToolResultItem{
+				{Type: "text", Text: string(resultJSON)},
+			},
+		}, nil
 	}
 
-	// Parse the raw DOM state data
 	var domStateData DomStateData
-	if err := t.parseResponseToStruct(resp.Result, &domStateData); err != nil {
-		t.logger.Error("Error parsing DOM state data for extra elements", zap.Error(err))
-		return types.ToolResult{}, fmt.Errorf("failed to parse DOM state data: %w", err)
+	var snapshotID string
+	var snapshotExpired bool
+
+	if params.PageToken != "" {
+		token, err := decodePageToken(params.PageToken)
+		if err != nil {
+			t.logger.Error("Invalid pageToken for get_dom_extra_elements", zap.Error(err))
+			return types.ToolResult{}, fmt.Errorf("invalid pageToken: %w", err)
+		}
+
+		if snapshot, ok := t.getSnapshot(token.SnapshotID); ok {
+			domStateData = snapshot.data
+			snapshotID = token.SnapshotID
+		} else {
+			// Snapshot expired or this host restarted: mirror the k8s list
+			// pager behavior of restarting the list from a fresh snapshot.
+			t.logger.Warn("DOM snapshot expired or unknown, restarting list", zap.String("snapshotId", token.SnapshotID))
+			data, newID, err := t.fetchAndCacheSnapshot()
+			if err != nil {
+				return types.ToolResult{}, err
+			}
+			domStateData = data
+			snapshotID = newID
+			snapshotExpired = true
+			token.Offset = 0
+		}
+
+		params.ElementType = token.Filter
+		params.PageSize = token.PageSize
+		params.StartIndex = token.Offset + 1
+	} else {
+		data, newID, err := t.fetchAndCacheSnapshot()
+		if err != nil {
+			return types.ToolResult{}, err
+		}
+		domStateData = data
+		snapshotID = newID
 	}
 
 	// Apply pagination and filtering
 	result := t.applyPaginationAndFiltering(domStateData, params)
+	result.SnapshotID = snapshotID
+	result.ResourceVersion = snapshotID
+	result.SnapshotExpired = snapshotExpired
+
+	if result.Pagination.HasNextPage {
+		result.NextPageToken = encodePageToken(pageTokenPayload{
+			SnapshotID:  snapshotID,
+			Offset:      result.Pagination.EndIndex,
+			Filter:      params.ElementType,
+			PageSize:    params.PageSize,
+			GeneratedAt: time.Now().UnixMilli(),
+		})
+	}
 
 	t.logger.Debug("Successfully retrieved extra DOM elements",
 		zap.Int("totalElements", result.Pagination.TotalElements),
@@ -154,6 +263,10 @@ type ExtraElementsParams struct {
 	PageSize    int    // Number of elements per page
 	ElementType string // Element type filter
 	StartIndex  int    // Optional: start from specific index (1-based)
+	PageToken   string // Optional: opaque cursor from a previous call's nextPageToken
+
+	Aggregate     bool // If true, merge every matching page into one response
+	DedupeByXPath bool // If true (and Aggregate), use DedupByXPathStrategy instead of AppendStrategy
 }
 
 // DomStateData represents the raw DOM state data from Chrome extension
@@ -168,6 +281,15 @@ type ExtraElementsResult struct {
 	Elements   []map[string]interface{} `json:"elements"`
 	Pagination PaginationInfo           `json:"pagination"`
 	Filter     *FilterInfo              `json:"filter,omitempty"`
+
+	// SnapshotID/ResourceVersion identify the stable DOM snapshot this result
+	// was read from; NextPageToken (if present) reads the next page from the
+	// same snapshot. SnapshotExpired is set when the original snapshot had
+	// aged out of the cache and the list was restarted from a fresh one.
+	SnapshotID      string `json:"snapshotId"`
+	ResourceVersion string `json:"resourceVersion"`
+	NextPageToken   string `json:"nextPageToken,omitempty"`
+	SnapshotExpired bool   `json:"snapshotExpired,omitempty"`
 }
 
 // PaginationInfo contains pagination metadata
@@ -238,7 +360,9 @@ func (t *GetDomExtraElementsTool) parseArguments(arguments map[string]interface{
 	}
 
 	// Parse startIndex parameter (optional)
+	startIndexProvided := false
 	if startIndexVal, exists := arguments["startIndex"]; exists && startIndexVal != nil {
+		startIndexProvided = true
 		if startIndexFloat, ok := startIndexVal.(float64); ok {
 			startIndex := int(startIndexFloat)
 			if startIndex < 1 {
@@ -250,6 +374,43 @@ func (t *GetDomExtraElementsTool) parseArguments(arguments map[string]interface{
 		}
 	}
 
+	// Parse pageToken parameter (optional, mutually exclusive with page/startIndex)
+	pageProvided := arguments["page"] != nil
+	if pageTokenVal, exists := arguments["pageToken"]; exists && pageTokenVal != nil {
+		pageTokenStr, ok := pageTokenVal.(string)
+		if !ok {
+			return params, fmt.Errorf("pageToken must be a string, got %T", pageTokenVal)
+		}
+		if pageTokenStr == "" {
+			return params, fmt.Errorf("pageToken must not be empty")
+		}
+		if pageProvided || startIndexProvided {
+			return params, fmt.Errorf("pageToken is mutually exclusive with page/startIndex")
+		}
+		params.PageToken = pageTokenStr
+	}
+
+	// Parse aggregate parameter (optional, mutually exclusive with page/startIndex/pageToken)
+	if aggregateVal, exists := arguments["aggregate"]; exists && aggregateVal != nil {
+		aggregate, ok := aggregateVal.(bool)
+		if !ok {
+			return params, fmt.Errorf("aggregate must be a boolean, got %T", aggregateVal)
+		}
+		if aggregate && (pageProvided || startIndexProvided || params.PageToken != "") {
+			return params, fmt.Errorf("aggregate is mutually exclusive with page/startIndex/pageToken")
+		}
+		params.Aggregate = aggregate
+	}
+
+	// Parse dedupeByXPath parameter (optional)
+	if dedupeVal, exists := arguments["dedupeByXPath"]; exists && dedupeVal != nil {
+		dedupe, ok := dedupeVal.(bool)
+		if !ok {
+			return params, fmt.Errorf("dedupeByXPath must be a boolean, got %T", dedupeVal)
+		}
+		params.DedupeByXPath = dedupe
+	}
+
 	return params, nil
 }
 
@@ -362,3 +523,104 @@ func (t *GetDomExtraElementsTool) calculateTotalPages(totalElements, pageSize in
 	// Manual ceiling calculation: (totalElements + pageSize - 1) / pageSize
 	return (totalElements + pageSize - 1) / pageSize
 }
+
+// fetchAndCacheSnapshot requests a fresh DOM state from the extension and
+// pins it under a new snapshotId so subsequent cursor-paginated calls see a
+// stable view of the collection.
+func (t *GetDomExtraElementsTool) fetchAndCacheSnapshot() (DomStateData, string, error) {
+	resp, err := t.driver.RpcRequest(types.RpcRequest{
+		Method: "get_dom_state",
+	}, types.RpcOptions{Timeout: 5000})
+
+	if err != nil {
+		t.logger.Error("Error requesting DOM state for extra elements", zap.Error(err))
+		return DomStateData{}, "", fmt.Errorf("failed to request DOM state: %w", err)
+	}
+
+	if resp.Error != nil {
+		t.logger.Error("RPC error getting DOM state for extra elements", zap.Any("respError", resp.Error))
+		return DomStateData{}, "", fmt.Errorf("RPC error: %s", resp.Error.Message)
+	}
+
+	var domStateData DomStateData
+	if err := t.parseResponseToStruct(resp.Result, &domStateData); err != nil {
+		t.logger.Error("Error parsing DOM state data for extra elements", zap.Error(err))
+		return DomStateData{}, "", fmt.Errorf("failed to parse DOM state data: %w", err)
+	}
+
+	snapshotID := uuid.NewString()
+
+	t.snapshotsMu.Lock()
+	t.snapshots[snapshotID] = &domSnapshot{data: domStateData, createdAt: time.Now()}
+	t.snapshotsMu.Unlock()
+
+	return domStateData, snapshotID, nil
+}
+
+// getSnapshot returns a cached snapshot if it exists and has not yet expired.
+func (t *GetDomExtraElementsTool) getSnapshot(snapshotID string) (*domSnapshot, bool) {
+	t.snapshotsMu.Lock()
+	defer t.snapshotsMu.Unlock()
+
+	snapshot, exists := t.snapshots[snapshotID]
+	if !exists || time.Since(snapshot.createdAt) > snapshotTTL {
+		return nil, false
+	}
+	return snapshot, true
+}
+
+// evictSnapshots drops expired snapshots and, if the cache is still over
+// capacity, the oldest remaining ones. Called from Execute so the cache
+// never needs a background goroutine.
+func (t *GetDomExtraElementsTool) evictSnapshots() {
+	t.snapshotsMu.Lock()
+	defer t.snapshotsMu.Unlock()
+
+	now := time.Now()
+	for id, snapshot := range t.snapshots {
+		if now.Sub(snapshot.createdAt) > snapshotTTL {
+			delete(t.snapshots, id)
+		}
+	}
+
+	for len(t.snapshots) > maxCachedSnapshots {
+		var oldestID string
+		var oldestAt time.Time
+		for id, snapshot := range t.snapshots {
+			if oldestID == "" || snapshot.createdAt.Before(oldestAt) {
+				oldestID = id
+				oldestAt = snapshot.createdAt
+			}
+		}
+		delete(t.snapshots, oldestID)
+	}
+}
+
+// encodePageToken serializes a pageTokenPayload into an opaque cursor string.
+func encodePageToken(payload pageTokenPayload) string {
+	jsonBytes, err := json.Marshal(payload)
+	if err != nil {
+		// payload is a plain struct of primitives; marshaling cannot fail.
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(jsonBytes)
+}
+
+// decodePageToken parses an opaque cursor string back into a pageTokenPayload.
+func decodePageToken(token string) (pageTokenPayload, error) {
+	jsonBytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageTokenPayload{}, fmt.Errorf("malformed pageToken: %w", err)
+	}
+
+	var payload pageTokenPayload
+	if err := json.Unmarshal(jsonBytes, &payload); err != nil {
+		return pageTokenPayload{}, fmt.Errorf("malformed pageToken: %w", err)
+	}
+
+	if payload.SnapshotID == "" {
+		return pageTokenPayload{}, fmt.Errorf("pageToken is missing snapshotId")
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,119 @@
+package tools
+
+import "fmt"
+
+// maxAggregatedElements caps an aggregate=true response so a pathologically
+// large DOM can't be shipped in a single oversized Native Messaging payload.
+const maxAggregatedElements = 1000
+
+// MergeStrategy combines the elements collected so far with the next page of
+// elements. It is pluggable so aggregate=true can either concatenate pages
+// verbatim or deduplicate sticky elements (e.g. nav bars repeated on every
+// page) across the walk.
+type MergeStrategy interface {
+	Merge(prev, next []map[string]interface{}) []map[string]interface{}
+}
+
+// AppendStrategy concatenates every page's elements in order; it is the
+// default merge strategy.
+type AppendStrategy struct{}
+
+// Merge implements MergeStrategy.
+func (AppendStrategy) Merge(prev, next []map[string]interface{}) []map[string]interface{} {
+	return append(prev, next...)
+}
+
+// DedupByXPathStrategy drops elements whose xpath (or selector, if xpath is
+// absent) was already seen in an earlier page.
+type DedupByXPathStrategy struct {
+	seen map[string]bool
+}
+
+// Merge implements MergeStrategy.
+func (s *DedupByXPathStrategy) Merge(prev, next []map[string]interface{}) []map[string]interface{} {
+	if s.seen == nil {
+		s.seen = make(map[string]bool, len(prev))
+		for _, el := range prev {
+			if key := elementDedupeKey(el); key != "" {
+				s.seen[key] = true
+			}
+		}
+	}
+
+	merged := prev
+	for _, el := range next {
+		key := elementDedupeKey(el)
+		if key != "" && s.seen[key] {
+			continue
+		}
+		if key != "" {
+			s.seen[key] = true
+		}
+		merged = append(merged, el)
+	}
+	return merged
+}
+
+// elementDedupeKey returns the xpath or, failing that, the selector of an
+// element, or "" if neither is present.
+func elementDedupeKey(el map[string]interface{}) string {
+	if xpath, ok := el["xpath"].(string); ok && xpath != "" {
+		return xpath
+	}
+	if selector, ok := el["selector"].(string); ok && selector != "" {
+		return selector
+	}
+	return ""
+}
+
+// aggregateElements walks every page of a filtered snapshot under a single
+// MergeStrategy and returns a single merged ExtraElementsResult whose
+// Pagination reflects the full set (CurrentPage=1, TotalPages=1,
+// HasNextPage=false).
+func (t *GetDomExtraElementsTool) aggregateElements(params ExtraElementsParams) (ExtraElementsResult, error) {
+	var strategy MergeStrategy = AppendStrategy{}
+	if params.DedupeByXPath {
+		strategy = &DedupByXPathStrategy{}
+	}
+
+	var merged []map[string]interface{}
+	var filterInfo *FilterInfo
+
+	err := t.EachElement(params.ElementType, func(el map[string]interface{}) error {
+		merged = strategy.Merge(merged, []map[string]interface{}{el})
+		if len(merged) > maxAggregatedElements {
+			return fmt.Errorf("aggregated result exceeds maxAggregatedElements (%d); narrow the elementType filter or use page-based access instead", maxAggregatedElements)
+		}
+		return nil
+	})
+	if err != nil {
+		return ExtraElementsResult{}, err
+	}
+
+	if params.ElementType != "all" {
+		filterInfo = &FilterInfo{ElementType: params.ElementType}
+	}
+
+	total := len(merged)
+	return ExtraElementsResult{
+		Elements: merged,
+		Filter:   filterInfo,
+		Pagination: PaginationInfo{
+			CurrentPage:     1,
+			PageSize:        total,
+			TotalElements:   total,
+			TotalPages:      1,
+			HasNextPage:     false,
+			HasPreviousPage: false,
+			StartIndex:      minInt(total, 1),
+			EndIndex:        total,
+		},
+	}, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
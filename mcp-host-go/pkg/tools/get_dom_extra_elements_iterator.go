@@ -0,0 +1,114 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrStopIteration is returned by an EachElement callback to stop walking the
+// result set early without that being treated as a failure.
+var ErrStopIteration = errors.New("tools: stop iteration")
+
+// iteratorPageSize is the page size used internally when walking the full
+// result set; callers never see it directly.
+const iteratorPageSize = 100
+
+// PageInfo describes where an in-progress iteration currently stands. It is
+// available via PageInfo() between calls to the iterator's callback so other
+// MCP tools can report progress while composing over paginated DOM data.
+type PageInfo struct {
+	CurrentPage   int
+	TotalPages    int
+	TotalElements int
+}
+
+// pageInfoState holds the PageInfo the last iteration run observed, guarded
+// by a mutex since EachElement/AllElements may be called concurrently by
+// different callers.
+type pageInfoState struct {
+	mu   sync.Mutex
+	info PageInfo
+}
+
+func (s *pageInfoState) set(info PageInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.info = info
+}
+
+func (s *pageInfoState) get() PageInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.info
+}
+
+// EachElement walks every interactive element matching filter (one of
+// button, input, link, select, textarea, all), a page at a time under a
+// single DOM snapshot so the set cannot shift mid-walk. fn is invoked once
+// per element; returning ErrStopIteration halts the walk early without
+// propagating an error, any other error aborts and is returned as-is.
+func (t *GetDomExtraElementsTool) EachElement(filter string, fn func(el map[string]interface{}) error) error {
+	if !t.isValidElementType(filter) {
+		return fmt.Errorf("invalid elementType: %s, must be one of: button, input, link, select, textarea, all", filter)
+	}
+
+	data, snapshotID, err := t.fetchAndCacheSnapshot()
+	if err != nil {
+		return err
+	}
+
+	params := ExtraElementsParams{Page: 1, PageSize: iteratorPageSize, ElementType: filter}
+
+	for {
+		if snapshot, ok := t.getSnapshot(snapshotID); ok {
+			data = snapshot.data
+		}
+
+		page := t.applyPaginationAndFiltering(data, params)
+		t.pageInfo.set(PageInfo{
+			CurrentPage:   page.Pagination.CurrentPage,
+			TotalPages:    page.Pagination.TotalPages,
+			TotalElements: page.Pagination.TotalElements,
+		})
+
+		for _, el := range page.Elements {
+			if err := fn(el); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if !page.Pagination.HasNextPage {
+			return nil
+		}
+		params.Page++
+	}
+}
+
+// AllElements accumulates and returns every interactive element matching
+// filter, reusing the same snapshot-token mechanism as EachElement so results
+// stay consistent across pages. maxElements caps how many elements are
+// collected (<= 0 means unbounded) to prevent a runaway page from exhausting
+// memory.
+func (t *GetDomExtraElementsTool) AllElements(filter string, maxElements int) ([]map[string]interface{}, error) {
+	elements := make([]map[string]interface{}, 0)
+
+	err := t.EachElement(filter, func(el map[string]interface{}) error {
+		elements = append(elements, el)
+		if maxElements > 0 && len(elements) >= maxElements {
+			return ErrStopIteration
+		}
+		return nil
+	})
+
+	return elements, err
+}
+
+// PageInfo returns a snapshot of the most recently observed page during an
+// EachElement/AllElements walk on this tool instance.
+func (t *GetDomExtraElementsTool) PageInfo() PageInfo {
+	return t.pageInfo.get()
+}
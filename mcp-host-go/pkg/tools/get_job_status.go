@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/jobs"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// GetJobStatusTool implements a tool for polling the state of a job
+// previously queued via an async tool call (e.g. navigate_to with
+// "async": true).
+type GetJobStatusTool struct {
+	name        string
+	description string
+	logger      logger.Logger
+	jobs        *jobs.Manager
+}
+
+// GetJobStatusConfig contains configuration for GetJobStatusTool
+type GetJobStatusConfig struct {
+	Logger logger.Logger
+	Jobs   *jobs.Manager
+}
+
+// NewGetJobStatusTool creates a new GetJobStatusTool
+func NewGetJobStatusTool(config GetJobStatusConfig) (*GetJobStatusTool, error) {
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if config.Jobs == nil {
+		return nil, fmt.Errorf("jobs manager is required")
+	}
+
+	return &GetJobStatusTool{
+		name:        "get_job_status",
+		description: "Get the current status of an async job queued by another tool (e.g. navigate_to with async: true)",
+		logger:      config.Logger,
+		jobs:        config.Jobs,
+	}, nil
+}
+
+// GetName returns the tool name
+func (t *GetJobStatusTool) GetName() string { return t.name }
+
+// GetDescription returns the tool description
+func (t *GetJobStatusTool) GetDescription() string { return t.description }
+
+// GetInputSchema returns the tool input schema
+func (t *GetJobStatusTool) GetInputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"job_id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID returned when the job was queued",
+			},
+		},
+		"required":             []string{"job_id"},
+		"additionalProperties": false,
+	}
+}
+
+// Execute executes the get_job_status tool
+func (t *GetJobStatusTool) Execute(args map[string]interface{}) (types.ToolResult, error) {
+	jobID, ok := args["job_id"].(string)
+	if !ok || jobID == "" {
+		return types.ToolResult{}, fmt.Errorf("job_id is required and must be a string")
+	}
+
+	job, ok := t.jobs.Get(jobID)
+	if !ok {
+		return types.ToolResult{}, fmt.Errorf("job not found: %s", jobID)
+	}
+
+	body, err := json.Marshal(job)
+	if err != nil {
+		return types.ToolResult{}, fmt.Errorf("failed to marshal job status: %w", err)
+	}
+
+	return types.ToolResult{
+		Content: []types.ToolResultItem{
+			{Type: "text", Text: string(body)},
+		},
+	}, nil
+}
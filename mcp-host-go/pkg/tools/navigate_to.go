@@ -3,7 +3,9 @@ package tools
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/jobs"
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
 	"go.uber.org/zap"
@@ -14,13 +16,19 @@ type NavigateToTool struct {
 	name        string
 	description string
 	logger      logger.Logger
-	messaging   types.Messaging
+	driver      types.BrowserDriver
+	jobs        *jobs.Manager
 }
 
 // NavigateToConfig contains configuration for NavigateToTool
 type NavigateToConfig struct {
-	Logger    logger.Logger
-	Messaging types.Messaging
+	Logger logger.Logger
+	Driver types.BrowserDriver
+
+	// Jobs, if set, lets the tool honor the "async" argument by enqueuing
+	// the navigation instead of running it synchronously. If nil, "async"
+	// requests are rejected.
+	Jobs *jobs.Manager
 }
 
 // NewNavigateToTool creates a new NavigateToTool
@@ -29,15 +37,16 @@ func NewNavigateToTool(config NavigateToConfig) (*NavigateToTool, error) {
 		return nil, fmt.Errorf("logger is required")
 	}
 
-	if config.Messaging == nil {
-		return nil, fmt.Errorf("messaging is required")
+	if config.Driver == nil {
+		return nil, fmt.Errorf("driver is required")
 	}
 
 	return &NavigateToTool{
 		name:        "navigate_to",
 		description: "Navigate to a specified URL",
 		logger:      config.Logger,
-		messaging:   config.Messaging,
+		driver:      config.Driver,
+		jobs:        config.Jobs,
 	}, nil
 }
 
@@ -51,6 +60,10 @@ func (t *NavigateToTool) GetDescription() string {
 	return t.description
 }
 
+// validWaitUntilValues lists the navigation wait strategies accepted by the
+// wait_until parameter.
+var validWaitUntilValues = []string{"load", "domcontentloaded", "networkidle0", "networkidle2", "selector"}
+
 // GetInputSchema returns the tool input schema
 func (t *NavigateToTool) GetInputSchema() interface{} {
 	return map[string]interface{}{
@@ -65,6 +78,22 @@ func (t *NavigateToTool) GetInputSchema() interface{} {
 				"description": "Navigation timeout: 'auto' for intelligent detection or timeout in milliseconds (e.g. '5000')",
 				"default":     "auto",
 			},
+			"wait_until": map[string]interface{}{
+				"type":        "string",
+				"description": "Navigation wait strategy: 'load', 'domcontentloaded', 'networkidle0' (no in-flight requests for 500ms), 'networkidle2' (<=2 in-flight requests for 500ms), or 'selector' (requires wait_for_selector)",
+				"enum":        validWaitUntilValues,
+				"default":     "load",
+			},
+			"wait_for_selector": map[string]interface{}{
+				"type":        "string",
+				"description": "CSS selector to wait for; required when wait_until is 'selector'",
+			},
+			"async": map[string]interface{}{
+				"type":        "boolean",
+				"description": "If true, enqueue the navigation and return immediately with a job_id instead of blocking; poll it with get_job_status or read browser://jobs/{id}. Not supported together with retry, since the job queue issues the driver call exactly once",
+				"default":     false,
+			},
+			"retry": retryInputSchemaProperty(),
 		},
 		"required":             []string{"url"},
 		"additionalProperties": false,
@@ -103,16 +132,51 @@ func (t *NavigateToTool) Execute(args map[string]interface{}) (types.ToolResult,
 		}
 	}
 
-	t.logger.Info("Navigate to URL with timeout", zap.String("url", url), zap.String("timeout", timeoutStr), zap.Int("rpcTimeout", rpcTimeout))
+	// Handle wait_until parameter
+	waitUntil := "load" // default value
+	if waitUntilArg, ok := args["wait_until"].(string); ok && waitUntilArg != "" {
+		if !isValidWaitUntil(waitUntilArg) {
+			return types.ToolResult{}, fmt.Errorf("wait_until must be one of: %s", strings.Join(validWaitUntilValues, ", "))
+		}
+		waitUntil = waitUntilArg
+	}
 
-	// Send RPC request to the extension
-	resp, err := t.messaging.RpcRequest(types.RpcRequest{
-		Method: "navigate_to",
-		Params: map[string]interface{}{
-			"url":     url,
-			"timeout": timeoutStr,
-		},
-	}, types.RpcOptions{Timeout: rpcTimeout + 5000}) // Add 5 seconds buffer for RPC timeout
+	waitForSelector, _ := args["wait_for_selector"].(string)
+	if waitUntil == "selector" && waitForSelector == "" {
+		return types.ToolResult{}, fmt.Errorf("wait_for_selector is required when wait_until is 'selector'")
+	}
+
+	retryCfg, err := parseRetryConfig(args)
+	if err != nil {
+		return types.ToolResult{}, err
+	}
+
+	t.logger.Info("Navigate to URL with timeout", zap.String("url", url), zap.String("timeout", timeoutStr), zap.Int("rpcTimeout", rpcTimeout), zap.String("waitUntil", waitUntil))
+
+	if async, _ := args["async"].(bool); async {
+		// The job queue has no retry semantics of its own (jobs.Manager
+		// issues each job's driver call exactly once), so silently
+		// accepting a retry block here would make it look honored when
+		// it's actually discarded. Reject the combination instead.
+		if _, hasRetry := args["retry"]; hasRetry {
+			return types.ToolResult{}, fmt.Errorf("retry is not supported together with async: true; submit without retry, or drop async to run synchronously with retries")
+		}
+		return t.executeAsync(url, timeoutStr, waitUntil, waitForSelector)
+	}
+
+	// Send RPC request to the extension, retrying on transport failures and
+	// on any error code the caller opted into via retry.retry_on.
+	resp, err := withRetry(t.logger, t.name, retryCfg, func(attempt int) (types.RpcResponse, error) {
+		return t.driver.RpcRequest(types.RpcRequest{
+			Method: "navigate_to",
+			Params: map[string]interface{}{
+				"url":               url,
+				"timeout":           timeoutStr,
+				"wait_until":        waitUntil,
+				"wait_for_selector": waitForSelector,
+			},
+		}, types.RpcOptions{Timeout: rpcTimeout + 5000}) // Add 5 seconds buffer for RPC timeout
+	}, nil)
 
 	if err != nil {
 		t.logger.Error("Error calling navigate_to", zap.Error(err))
@@ -124,13 +188,63 @@ func (t *NavigateToTool) Execute(args map[string]interface{}) (types.ToolResult,
 		return types.ToolResult{}, fmt.Errorf("RPC error: %s", resp.Error.Message)
 	}
 
+	// Pull out what condition actually fired and the final URL after any
+	// redirects, if the extension reported them.
+	finalURL := url
+	firedCondition := waitUntil
+	if resultData, ok := resp.Result.(map[string]interface{}); ok {
+		if v, ok := resultData["finalUrl"].(string); ok && v != "" {
+			finalURL = v
+		}
+		if v, ok := resultData["waitCondition"].(string); ok && v != "" {
+			firedCondition = v
+		}
+	}
+
 	// Return enhanced result
 	return types.ToolResult{
 		Content: []types.ToolResultItem{
 			{
 				Type: "text",
-				Text: fmt.Sprintf("Successfully navigated to %s (strategy: %s)", url, timeoutStr),
+				Text: fmt.Sprintf("Successfully navigated to %s (wait condition: %s)", finalURL, firedCondition),
 			},
 		},
 	}, nil
 }
+
+// executeAsync enqueues the navigation as a job and returns its ID
+// immediately instead of waiting for the navigation to finish.
+func (t *NavigateToTool) executeAsync(url, timeoutStr, waitUntil, waitForSelector string) (types.ToolResult, error) {
+	if t.jobs == nil {
+		return types.ToolResult{}, fmt.Errorf("async navigation is not available: no job manager configured")
+	}
+
+	jobID, err := t.jobs.Submit("navigate_to", map[string]interface{}{
+		"url":               url,
+		"timeout":           timeoutStr,
+		"wait_until":        waitUntil,
+		"wait_for_selector": waitForSelector,
+	})
+	if err != nil {
+		return types.ToolResult{}, fmt.Errorf("failed to enqueue navigation: %w", err)
+	}
+
+	return types.ToolResult{
+		Content: []types.ToolResultItem{
+			{
+				Type: "text",
+				Text: fmt.Sprintf("Navigation to %s queued as job %s", url, jobID),
+			},
+		},
+	}, nil
+}
+
+// isValidWaitUntil checks if a wait_until value is supported
+func isValidWaitUntil(value string) bool {
+	for _, valid := range validWaitUntilValues {
+		if valid == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/jobs"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+type fakeLogger struct{}
+
+func (fakeLogger) Error(string, ...interface{}) {}
+func (fakeLogger) Warn(string, ...interface{})  {}
+func (fakeLogger) Info(string, ...interface{})  {}
+func (fakeLogger) Debug(string, ...interface{}) {}
+
+func (l fakeLogger) With(...logger.Field) logger.Logger { return l }
+
+type fakeDriver struct{}
+
+func (fakeDriver) RpcRequest(types.RpcRequest, types.RpcOptions) (types.RpcResponse, error) {
+	return types.RpcResponse{Result: map[string]interface{}{"finalUrl": "https://example.com", "waitCondition": "load"}}, nil
+}
+
+func TestNavigateTo_AsyncWithRetryIsRejected(t *testing.T) {
+	tool, err := NewNavigateToTool(NavigateToConfig{Logger: fakeLogger{}, Driver: fakeDriver{}})
+	if err != nil {
+		t.Fatalf("NewNavigateToTool: %v", err)
+	}
+
+	_, err = tool.Execute(map[string]interface{}{
+		"url":   "https://example.com",
+		"async": true,
+		"retry": map[string]interface{}{"max_attempts": float64(5)},
+	})
+	if err == nil {
+		t.Fatalf("expected async+retry to be rejected, got nil error")
+	}
+}
+
+func TestNavigateTo_AsyncWithoutRetryIsAccepted(t *testing.T) {
+	mgr, err := jobs.NewManager(jobs.ManagerConfig{Logger: fakeLogger{}, Driver: fakeDriver{}, Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	defer mgr.Shutdown()
+
+	tool, err := NewNavigateToTool(NavigateToConfig{Logger: fakeLogger{}, Driver: fakeDriver{}, Jobs: mgr})
+	if err != nil {
+		t.Fatalf("NewNavigateToTool: %v", err)
+	}
+
+	result, err := tool.Execute(map[string]interface{}{
+		"url":   "https://example.com",
+		"async": true,
+	})
+	if err != nil {
+		t.Fatalf("expected async without retry to be accepted, got error: %v", err)
+	}
+	if len(result.Content) == 0 {
+		t.Fatalf("expected a job_id response")
+	}
+}
+
+func TestNavigateTo_SyncWithRetryStillWorks(t *testing.T) {
+	tool, err := NewNavigateToTool(NavigateToConfig{Logger: fakeLogger{}, Driver: fakeDriver{}})
+	if err != nil {
+		t.Fatalf("NewNavigateToTool: %v", err)
+	}
+
+	_, err = tool.Execute(map[string]interface{}{
+		"url":   "https://example.com",
+		"retry": map[string]interface{}{"max_attempts": float64(2)},
+	})
+	if err != nil {
+		t.Fatalf("expected sync call with retry to succeed, got error: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+	"go.uber.org/zap"
+)
+
+// Defaults for a tool's "retry" input, used whenever the caller omits the
+// block entirely or a given field within it.
+const (
+	defaultMaxAttempts     = 3
+	defaultInitialBackoff  = 100 * time.Millisecond
+	maxRetryBackoff        = 800 * time.Millisecond
+	maxConfigurableAttempt = 10
+)
+
+// retryConfig controls how withRetry re-issues a failed RPC call.
+type retryConfig struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	RetryOnCodes   map[string]bool
+}
+
+func defaultRetryConfig() retryConfig {
+	return retryConfig{
+		MaxAttempts:    defaultMaxAttempts,
+		InitialBackoff: defaultInitialBackoff,
+		RetryOnCodes:   map[string]bool{},
+	}
+}
+
+// retryInputSchemaProperty is the "retry" block every retry-capable tool
+// embeds in its input schema.
+func retryInputSchemaProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"max_attempts": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of attempts, including the first",
+				"minimum":     1,
+				"maximum":     maxConfigurableAttempt,
+				"default":     defaultMaxAttempts,
+			},
+			"initial_backoff_ms": map[string]interface{}{
+				"type":        "integer",
+				"description": "Backoff before the second attempt, in milliseconds; each later backoff doubles the previous one (with jitter), capped at 800ms",
+				"minimum":     10,
+				"maximum":     5000,
+				"default":     100,
+			},
+			"retry_on": map[string]interface{}{
+				"type":        "array",
+				"description": "Error codes that should trigger a retry (e.g. \"ELEMENT_NOT_FOUND\", \"NAVIGATION_TIMEOUT\"); transport-level RPC failures are always retried regardless of this list",
+				"items":       map[string]interface{}{"type": "string"},
+				"default":     []string{},
+			},
+		},
+		"additionalProperties": false,
+	}
+}
+
+// parseRetryConfig extracts the optional "retry" block from a tool's args.
+func parseRetryConfig(args map[string]interface{}) (retryConfig, error) {
+	cfg := defaultRetryConfig()
+
+	retryArg, exists := args["retry"]
+	if !exists {
+		return cfg, nil
+	}
+
+	retryMap, ok := retryArg.(map[string]interface{})
+	if !ok {
+		return retryConfig{}, fmt.Errorf("retry must be an object")
+	}
+
+	for key, value := range retryMap {
+		switch key {
+		case "max_attempts":
+			n, ok := toInt(value)
+			if !ok || n < 1 || n > maxConfigurableAttempt {
+				return retryConfig{}, fmt.Errorf("retry.max_attempts must be an integer between 1 and %d", maxConfigurableAttempt)
+			}
+			cfg.MaxAttempts = n
+		case "initial_backoff_ms":
+			n, ok := toInt(value)
+			if !ok || n < 10 || n > 5000 {
+				return retryConfig{}, fmt.Errorf("retry.initial_backoff_ms must be an integer between 10 and 5000")
+			}
+			cfg.InitialBackoff = time.Duration(n) * time.Millisecond
+		case "retry_on":
+			codes, ok := value.([]interface{})
+			if !ok {
+				return retryConfig{}, fmt.Errorf("retry.retry_on must be an array of strings")
+			}
+			for _, c := range codes {
+				code, ok := c.(string)
+				if !ok {
+					return retryConfig{}, fmt.Errorf("retry.retry_on must be an array of strings")
+				}
+				cfg.RetryOnCodes[code] = true
+			}
+		default:
+			return retryConfig{}, fmt.Errorf("unknown retry option: %s", key)
+		}
+	}
+
+	return cfg, nil
+}
+
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+// withRetry runs call up to cfg.MaxAttempts times, retrying on transport
+// errors unconditionally and on RPC-level/domain errors only when their
+// code is in cfg.RetryOnCodes. extractCode inspects a successful transport
+// round-trip's result for a domain error code (returning "" when the call
+// actually succeeded); it may be nil if a tool has no such concept.
+func withRetry(log logger.Logger, toolName string, cfg retryConfig, call func(attempt int) (types.RpcResponse, error), extractCode func(types.RpcResponse) string) (types.RpcResponse, error) {
+	backoff := cfg.InitialBackoff
+	var resp types.RpcResponse
+	var err error
+
+	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
+		resp, err = call(attempt)
+
+		retryable := false
+		switch {
+		case err != nil:
+			retryable = true
+		case resp.Error != nil:
+			retryable = cfg.RetryOnCodes[resp.Error.Message]
+		case extractCode != nil:
+			if code := extractCode(resp); code != "" {
+				retryable = cfg.RetryOnCodes[code]
+			}
+		}
+
+		if !retryable || attempt == cfg.MaxAttempts {
+			return resp, err
+		}
+
+		wait := withJitter(backoff)
+		log.Warn("Retrying tool RPC call",
+			zap.String("tool", toolName),
+			zap.Int("attempt", attempt),
+			zap.Int("max_attempts", cfg.MaxAttempts),
+			zap.Duration("backoff", wait),
+			zap.Error(err))
+
+		time.Sleep(wait)
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+
+	return resp, err
+}
+
+// withJitter returns d plus up to 25% random jitter, so concurrent retries
+// triggered by the same upstream blip don't all land on the same tick.
+func withJitter(d time.Duration) time.Duration {
+	jitter := time.Duration(rand.Int63n(int64(d)/4 + 1))
+	return d + jitter
+}
@@ -1,26 +1,49 @@
 package tools
 
 import (
+	"encoding/base64"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/logger"
 	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// defaultMaxFileUploadSize is the per-file cap used when
+// SetValueConfig.MaxFileUploadSize is left at zero.
+const defaultMaxFileUploadSize = 25 * 1024 * 1024 // 25MB
+
 // SetValueTool implements a tool for setting values on interactive elements
 type SetValueTool struct {
 	name        string
 	description string
 	logger      logger.Logger
+	driver      types.BrowserDriver
 	messaging   types.Messaging
+
+	maxFileUploadSize int
 }
 
 // SetValueConfig contains configuration for SetValueTool
 type SetValueConfig struct {
-	Logger    logger.Logger
+	Logger logger.Logger
+
+	// Driver resolves description targets against the current DOM state
+	// (read-only), the same role it plays for NavigateToTool and
+	// CurrentStateResource.
+	Driver types.BrowserDriver
+
+	// Messaging carries the actual set_value RPC, which only the browser
+	// extension can execute.
 	Messaging types.Messaging
+
+	// MaxFileUploadSize caps the size (in bytes) of any single file accepted
+	// by the set_file upload mode. Defaults to defaultMaxFileUploadSize.
+	MaxFileUploadSize int
 }
 
 // NewSetValueTool creates a new SetValueTool
@@ -29,15 +52,26 @@ func NewSetValueTool(config SetValueConfig) (*SetValueTool, error) {
 		return nil, fmt.Errorf("logger is required")
 	}
 
+	if config.Driver == nil {
+		return nil, fmt.Errorf("driver is required")
+	}
+
 	if config.Messaging == nil {
 		return nil, fmt.Errorf("messaging is required")
 	}
 
+	maxFileUploadSize := config.MaxFileUploadSize
+	if maxFileUploadSize <= 0 {
+		maxFileUploadSize = defaultMaxFileUploadSize
+	}
+
 	return &SetValueTool{
-		name:        "set_value",
-		description: "Set values on interactive elements (text inputs, selects, checkboxes, etc.) using flexible targeting",
-		logger:      config.Logger,
-		messaging:   config.Messaging,
+		name:              "set_value",
+		description:       "Set values on interactive elements (text inputs, selects, checkboxes, file inputs, etc.) using flexible targeting",
+		logger:            config.Logger,
+		driver:            config.Driver,
+		messaging:         config.Messaging,
+		maxFileUploadSize: maxFileUploadSize,
 	}, nil
 }
 
@@ -72,7 +106,7 @@ func (t *SetValueTool) GetInputSchema() interface{} {
 				"description": "Target element (index or text description)",
 			},
 			"value": map[string]interface{}{
-				"description": "Value to set (string, number, boolean, or array for multi-select)",
+				"description": `Value to set (string, number, boolean, or array for multi-select). For <input type="file"> elements, pass an object in set_file mode: {"files":[{"path":"/abs/path","name":"x.pdf","mime":"application/pdf"}]} or {"files":[{"data_base64":"...","name":"x.pdf","mime":"..."}]}. Each file is capped at maxFileUploadSize (default 25MB).`,
 			},
 			"options": map[string]interface{}{
 				"type": "object",
@@ -97,6 +131,11 @@ func (t *SetValueTool) GetInputSchema() interface{} {
 				},
 				"additionalProperties": false,
 			},
+			"retry": retryInputSchemaProperty(),
+			"snapshot_id": map[string]interface{}{
+				"type":        "string",
+				"description": "snapshot_id from a prior browser://dom/query read; if the DOM has changed since (indices shifted, elements added/removed), Execute fails with a StaleSnapshotError instead of acting on a possibly-wrong element",
+			},
 		},
 		"required":             []string{"target", "value"},
 		"additionalProperties": false,
@@ -162,6 +201,38 @@ func (t *SetValueTool) Execute(args map[string]interface{}) (types.ToolResult, e
 		}
 	}
 
+	// Detect set_file mode: value is an object of the form {"files": [...]}
+	// rather than a plain scalar/array value.
+	var fileSpecs []interface{}
+	isFileUpload := false
+	if valueMap, ok := valueArg.(map[string]interface{}); ok {
+		filesArg, hasFiles := valueMap["files"]
+		if !hasFiles {
+			return types.ToolResult{}, fmt.Errorf("value object must contain a \"files\" array for file upload mode")
+		}
+		files, ok := filesArg.([]interface{})
+		if !ok || len(files) == 0 {
+			return types.ToolResult{}, fmt.Errorf("value.files must be a non-empty array")
+		}
+		fileSpecs = files
+		isFileUpload = true
+	}
+
+	var resolvedFiles []map[string]interface{}
+	if isFileUpload {
+		for i, spec := range fileSpecs {
+			specMap, ok := spec.(map[string]interface{})
+			if !ok {
+				return types.ToolResult{}, fmt.Errorf("value.files[%d] must be an object", i)
+			}
+			resolved, err := t.resolveFileSpec(specMap)
+			if err != nil {
+				return types.ToolResult{}, fmt.Errorf("value.files[%d]: %w", i, err)
+			}
+			resolvedFiles = append(resolvedFiles, resolved)
+		}
+	}
+
 	// Validate target parameter
 	var targetType string
 	switch target := targetArg.(type) {
@@ -184,25 +255,81 @@ func (t *SetValueTool) Execute(args map[string]interface{}) (types.ToolResult, e
 		return types.ToolResult{}, fmt.Errorf("target must be a number (index) or string (description)")
 	}
 
+	snapshotID, _ := args["snapshot_id"].(string)
+
+	// Resolve a description target against the current DOM state up front so
+	// the extension only ever sees an index, the same targeting it already
+	// understands for every other target_type. Reuse that same read to
+	// satisfy the snapshot_id check below instead of fetching DOM state twice.
+	if targetType == "description" {
+		domState, err := fetchDomState(t.driver)
+		if err != nil {
+			return types.ToolResult{}, fmt.Errorf("failed to read DOM state for description matching: %w", err)
+		}
+		if snapshotID != "" {
+			if actual := computeDomSnapshotID(domState.InteractiveElements); actual != snapshotID {
+				return types.ToolResult{}, &StaleSnapshotError{Expected: snapshotID, Actual: actual}
+			}
+			snapshotID = ""
+		}
+		index, err := matchDescription(domState, targetArg.(string), valueArg)
+		if err != nil {
+			return types.ToolResult{}, err
+		}
+		targetArg = float64(index)
+		targetType = "index"
+	}
+
+	if snapshotID != "" {
+		if err := verifySnapshot(t.driver, snapshotID); err != nil {
+			return types.ToolResult{}, err
+		}
+	}
+
+	retryCfg, err := parseRetryConfig(args)
+	if err != nil {
+		return types.ToolResult{}, err
+	}
+
+	// Generated once per Execute call (not per attempt) and echoed on every
+	// retry, so the extension can recognize a retried submit as a replay of
+	// one already in flight rather than firing it again.
+	idempotencyKey := uuid.NewString()
+
 	// Prepare RPC parameters
 	rpcParams := map[string]interface{}{
-		"target":      targetArg,
-		"value":       valueArg,
-		"options":     options,
-		"target_type": targetType,
+		"target":          targetArg,
+		"value":           valueArg,
+		"options":         options,
+		"target_type":     targetType,
+		"idempotency_key": idempotencyKey,
+	}
+
+	if isFileUpload {
+		rpcParams["files"] = resolvedFiles
+		rpcParams["input_method"] = "file_upload"
+		delete(rpcParams, "value")
 	}
 
 	t.logger.Debug("Sending set_value RPC request",
 		zap.Any("target", targetArg),
 		zap.String("target_type", targetType),
 		zap.Any("value", valueArg),
+		zap.Bool("file_upload", isFileUpload),
+		zap.String("idempotency_key", idempotencyKey),
 		zap.Any("options", options))
 
-	// Send RPC request to the extension
-	resp, err := t.messaging.RpcRequest(types.RpcRequest{
-		Method: "set_value",
-		Params: rpcParams,
-	}, types.RpcOptions{Timeout: 15000}) // 15 second timeout
+	// Send RPC request to the extension, retrying on transport failures and
+	// on any error code the caller opted into via retry.retry_on. Every
+	// attempt reuses the same idempotency_key so a retry after a partial
+	// success (e.g. the value was set but the submit's ack was lost) doesn't
+	// re-fire options.submit.
+	resp, err := withRetry(t.logger, t.name, retryCfg, func(attempt int) (types.RpcResponse, error) {
+		return t.messaging.RpcRequest(types.RpcRequest{
+			Method: "set_value",
+			Params: rpcParams,
+		}, types.RpcOptions{Timeout: 15000}) // 15 second timeout
+	}, setValueErrorCode)
 
 	if err != nil {
 		executionTime := time.Since(startTime).Seconds()
@@ -309,6 +436,36 @@ func (t *SetValueTool) Execute(args map[string]interface{}) (types.ToolResult, e
 		zap.Any("element_index", elementIndex),
 		zap.Float64("execution_time", executionTime))
 
+	// File uploads get their own response shape: a per-file confirmation
+	// instead of the single actualValue line used for scalar/array values.
+	if elementType == "file" {
+		responseText := fmt.Sprintf(`Set Value Result:
+- Status: Success
+- Message: %s
+- Target: %v
+- Element Type: file
+- Input Method: %s
+- Files Uploaded: %d
+- Execution Time: %.2f seconds`, message, targetArg, inputMethod, len(resolvedFiles), executionTime)
+
+		for _, f := range resolvedFiles {
+			responseText += fmt.Sprintf("\n  - %v (%v bytes)", f["name"], f["size"])
+		}
+
+		if elementIndex != nil {
+			responseText += fmt.Sprintf("\n- Element Index: %v", elementIndex)
+		}
+
+		return types.ToolResult{
+			Content: []types.ToolResultItem{
+				{
+					Type: "text",
+					Text: responseText,
+				},
+			},
+		}, nil
+	}
+
 	// Create detailed success response
 	responseText := fmt.Sprintf(`Set Value Result:
 - Status: Success
@@ -344,3 +501,81 @@ func (t *SetValueTool) Execute(args map[string]interface{}) (types.ToolResult, e
 		},
 	}, nil
 }
+
+// setValueErrorCode implements the extractCode hook withRetry uses to decide
+// whether a transport-successful but application-level failure is worth
+// retrying. It returns "" when the call actually succeeded.
+func setValueErrorCode(resp types.RpcResponse) string {
+	resultData, ok := resp.Result.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if success, ok := resultData["success"].(bool); ok && success {
+		return ""
+	}
+	if code, ok := resultData["error_code"].(string); ok {
+		return code
+	}
+	return ""
+}
+
+// resolveFileSpec normalizes a single entry of value.files into the shape
+// sent over RPC: {"name", "mime", "size", "data_base64"}. The spec may
+// reference a local file by "path" or embed its content directly via
+// "data_base64"; exactly one of the two must be present.
+func (t *SetValueTool) resolveFileSpec(spec map[string]interface{}) (map[string]interface{}, error) {
+	path, hasPath := spec["path"].(string)
+	dataB64, hasData := spec["data_base64"].(string)
+
+	if hasPath == hasData {
+		return nil, fmt.Errorf("exactly one of \"path\" or \"data_base64\" must be set")
+	}
+
+	var data []byte
+	var defaultName string
+
+	if hasPath {
+		if path == "" {
+			return nil, fmt.Errorf("path must not be empty")
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot stat file: %w", err)
+		}
+		if info.Size() > int64(t.maxFileUploadSize) {
+			return nil, fmt.Errorf("file size %d bytes exceeds maxFileUploadSize (%d bytes)", info.Size(), t.maxFileUploadSize)
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read file: %w", err)
+		}
+		data = contents
+		defaultName = filepath.Base(path)
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(dataB64)
+		if err != nil {
+			return nil, fmt.Errorf("data_base64 is not valid base64: %w", err)
+		}
+		if len(decoded) > t.maxFileUploadSize {
+			return nil, fmt.Errorf("file size %d bytes exceeds maxFileUploadSize (%d bytes)", len(decoded), t.maxFileUploadSize)
+		}
+		data = decoded
+	}
+
+	name, _ := spec["name"].(string)
+	if name == "" {
+		if defaultName == "" {
+			return nil, fmt.Errorf("name is required when uploading raw data_base64 content")
+		}
+		name = defaultName
+	}
+
+	mime, _ := spec["mime"].(string)
+
+	return map[string]interface{}{
+		"name":        name,
+		"mime":        mime,
+		"size":        len(data),
+		"data_base64": base64.StdEncoding.EncodeToString(data),
+	}, nil
+}
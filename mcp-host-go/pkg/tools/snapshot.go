@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/types"
+)
+
+// StaleSnapshotError is returned when a caller passes a snapshot_id that no
+// longer matches the current DOM state, meaning the index it resolved
+// earlier (e.g. from browser://dom/query) may now point at a different
+// element. The caller should re-query and retry rather than risk acting on
+// the wrong element.
+type StaleSnapshotError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *StaleSnapshotError) Error() string {
+	return fmt.Sprintf("stale DOM snapshot: expected snapshot_id %q but current DOM state is %q; re-query browser://dom/query and retry", e.Expected, e.Actual)
+}
+
+// computeDomSnapshotID fingerprints the (index, tagName, text) triple of
+// every interactive element, sorted by index, into a short hex digest. It
+// must stay in lockstep with resources.computeDomSnapshotID so a snapshot_id
+// minted by browser://dom/query can be verified here.
+func computeDomSnapshotID(elements []map[string]interface{}) string {
+	type fingerprintEntry struct {
+		index   int
+		tagName string
+		text    string
+	}
+
+	entries := make([]fingerprintEntry, 0, len(elements))
+	for _, element := range elements {
+		index, _ := intField(element, "index")
+		entries = append(entries, fingerprintEntry{
+			index:   index,
+			tagName: stringField(element, "tagName"),
+			text:    stringField(element, "text"),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].index < entries[j].index })
+
+	var b strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&b, "%d|%s|%s\n", e.index, e.tagName, e.text)
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// verifySnapshot re-fetches the current DOM state and returns a
+// *StaleSnapshotError if its fingerprint no longer matches expectedSnapshotID.
+func verifySnapshot(driver types.BrowserDriver, expectedSnapshotID string) error {
+	domState, err := fetchDomState(driver)
+	if err != nil {
+		return fmt.Errorf("failed to read DOM state for snapshot verification: %w", err)
+	}
+
+	actual := computeDomSnapshotID(domState.InteractiveElements)
+	if actual != expectedSnapshotID {
+		return &StaleSnapshotError{Expected: expectedSnapshotID, Actual: actual}
+	}
+	return nil
+}
@@ -59,7 +59,7 @@ func TestDomStatePagination(t *testing.T) {
 	})
 
 	// Verify resource is available
-	resources, err := testEnv.GetMcpClient().ListResources()
+	resources, err := testEnv.GetMcpClient().ListResources(ctx)
 	require.NoError(t, err)
 
 	found := false
@@ -78,7 +78,7 @@ func TestDomStatePagination(t *testing.T) {
 
 	t.Run("Basic DOM state resource access", func(t *testing.T) {
 		// Test that we can access the DOM state resource
-		resourceContent, err := testEnv.GetMcpClient().ReadResource("browser://dom/state")
+		resourceContent, err := testEnv.GetMcpClient().ReadResource(ctx, "browser://dom/state")
 		require.NoError(t, err)
 		require.NotEmpty(t, resourceContent.Contents)
 
@@ -112,7 +112,7 @@ func TestDomStatePagination(t *testing.T) {
 
 		for _, tc := range testCases {
 			t.Run(tc.description, func(t *testing.T) {
-				resourceContent, err := testEnv.GetMcpClient().ReadResource(tc.uri)
+				resourceContent, err := testEnv.GetMcpClient().ReadResource(ctx, tc.uri)
 				require.NoError(t, err)
 				require.NotEmpty(t, resourceContent.Contents)
 
@@ -130,7 +130,7 @@ func TestDomStatePagination(t *testing.T) {
 		// Test that the resource is properly configured to support pagination
 		// This validates our implementation structure even if we can't test
 		// the pagination arguments through the current MCP client
-		resourceContent, err := testEnv.GetMcpClient().ReadResource("browser://dom/state")
+		resourceContent, err := testEnv.GetMcpClient().ReadResource(ctx, "browser://dom/state")
 		require.NoError(t, err)
 		require.NotEmpty(t, resourceContent.Contents)
 
@@ -187,7 +187,7 @@ func TestDomStateElementFiltering(t *testing.T) {
 	})
 
 	// Test basic resource access
-	resourceContent, err := testEnv.GetMcpClient().ReadResource("browser://dom/state")
+	resourceContent, err := testEnv.GetMcpClient().ReadResource(ctx, "browser://dom/state")
 	require.NoError(t, err)
 	require.NotEmpty(t, resourceContent.Contents)
 
@@ -9,11 +9,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"time"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/mcpclient"
 )
 
 type McpHostTestEnvironment struct {
 	hostProcess *exec.Cmd
-	mcpClient   *MockMcpSSEClient
+	mcpClient   *mcpclient.McpSSEClient
 	nativeMsg   *NativeMessagingManager
 	port        int
 	baseURL     string
@@ -81,8 +83,11 @@ func (env *McpHostTestEnvironment) Setup(ctx context.Context) error {
 		return fmt.Errorf("MCP host failed to become ready: %w", err)
 	}
 
-	// Create MCP client
-	env.mcpClient = NewMockMcpSSEClient(env.baseURL + env.basePath)
+	// Create MCP client and connect it to the running host's SSE transport
+	env.mcpClient = mcpclient.NewMcpSSEClient(env.baseURL + env.basePath)
+	if err := env.mcpClient.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect MCP client: %w", err)
+	}
 
 	return nil
 }
@@ -184,7 +189,7 @@ func (env *McpHostTestEnvironment) IsHostRunning() bool {
 }
 
 // Accessor methods for private fields
-func (env *McpHostTestEnvironment) GetMcpClient() *MockMcpSSEClient {
+func (env *McpHostTestEnvironment) GetMcpClient() *mcpclient.McpSSEClient {
 	return env.mcpClient
 }
 
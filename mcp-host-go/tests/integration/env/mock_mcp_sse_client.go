@@ -11,13 +11,24 @@ import (
 	"time"
 )
 
-// MockMcpSSEClient simulates an MCP client that communicates via SSE
+// MockMcpSSEClient simulates an MCP client that communicates via SSE. It
+// predates pkg/mcpclient.McpSSEClient and talks a simplified, non-SSE
+// request/response shape; it is kept around for tests that only need
+// request/response plumbing and don't care about the real SSE transport.
+// New test code should prefer NewMockClient (or pkg/mcpclient directly for
+// tests that exercise the real transport).
 type MockMcpSSEClient struct {
 	baseURL    string
 	httpClient *http.Client
 	connected  bool
 }
 
+// NewMockClient is an alias for NewMockMcpSSEClient kept for call sites that
+// want a name decoupled from the concrete mock type.
+func NewMockClient(baseURL string) *MockMcpSSEClient {
+	return NewMockMcpSSEClient(baseURL)
+}
+
 // MCP protocol message types
 type ListResourcesResponse struct {
 	Resources []Resource `json:"resources"`
@@ -8,11 +8,47 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
 )
 
 // RpcHandler defines the signature for RPC method handlers
 type RpcHandler func(params map[string]interface{}) (interface{}, error)
 
+// rpcReply carries the result of a host-initiated RPC call back to the
+// goroutine blocked in SendRpcRequest.
+type rpcReply struct {
+	result interface{}
+	err    error
+}
+
+// Chrome's Native Messaging host protocol caps a single message at 1 MB
+// inbound to the extension and 64 MB outbound; oversize frames silently kill
+// the host. These are the defaults used when MaxInboundFrameSize/
+// MaxOutboundFrameSize are left at zero.
+const (
+	DefaultMaxInboundFrameSize  = 1 * 1024 * 1024
+	DefaultMaxOutboundFrameSize = 64 * 1024 * 1024
+
+	// chunkOverhead is reserved headroom for the {"type":"chunk",...}
+	// envelope so a chunk's total framed size never exceeds the outbound
+	// limit once its payload is wrapped.
+	chunkOverhead = 1024
+)
+
+// ErrMessageTooLarge is returned when a frame exceeds the configured inbound
+// or outbound size limit.
+type ErrMessageTooLarge struct {
+	Direction string // "inbound" or "outbound"
+	Size      int
+	Limit     int
+}
+
+func (e *ErrMessageTooLarge) Error() string {
+	return fmt.Sprintf("%s message too large: %d bytes exceeds limit of %d bytes", e.Direction, e.Size, e.Limit)
+}
+
 // NativeMessagingManager handles communication with the MCP host process via Native Messaging protocol
 type NativeMessagingManager struct {
 	stdin         io.WriteCloser
@@ -23,6 +59,42 @@ type NativeMessagingManager struct {
 	errors        chan error
 	actionHandler func(action string, params map[string]interface{}) map[string]interface{}
 	rpcHandlers   map[string]RpcHandler // method name -> handler
+
+	nextRequestID int64
+	pendingMu     sync.Mutex
+	pending       map[int64]chan rpcReply
+
+	// MaxInboundFrameSize/MaxOutboundFrameSize override the Native Messaging
+	// frame limits; zero means use DefaultMaxInboundFrameSize/
+	// DefaultMaxOutboundFrameSize. Exposed so tests can shrink them to
+	// exercise the chunking and rejection paths without 1MB+ fixtures.
+	MaxInboundFrameSize  int
+	MaxOutboundFrameSize int
+
+	nextStreamID int64
+	chunksMu     sync.Mutex
+	chunks       map[int64]*chunkAssembly
+}
+
+// chunkAssembly buffers the pieces of a chunked message until every seq
+// 0..total-1 has arrived.
+type chunkAssembly struct {
+	total    int
+	payloads map[int]string
+}
+
+func (nm *NativeMessagingManager) maxInboundFrameSize() int {
+	if nm.MaxInboundFrameSize > 0 {
+		return nm.MaxInboundFrameSize
+	}
+	return DefaultMaxInboundFrameSize
+}
+
+func (nm *NativeMessagingManager) maxOutboundFrameSize() int {
+	if nm.MaxOutboundFrameSize > 0 {
+		return nm.MaxOutboundFrameSize
+	}
+	return DefaultMaxOutboundFrameSize
 }
 
 func (nm *NativeMessagingManager) SendMessage(ctx context.Context, message map[string]interface{}) error {
@@ -32,6 +104,17 @@ func (nm *NativeMessagingManager) SendMessage(ctx context.Context, message map[s
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
+	return nm.writeFrame(jsonData)
+}
+
+// writeFrame validates the outbound frame size and writes the length-prefixed
+// frame to stdin.
+func (nm *NativeMessagingManager) writeFrame(jsonData []byte) error {
+	limit := nm.maxOutboundFrameSize()
+	if len(jsonData) > limit {
+		return &ErrMessageTooLarge{Direction: "outbound", Size: len(jsonData), Limit: limit}
+	}
+
 	// Write length prefix (4 bytes, little endian)
 	length := uint32(len(jsonData))
 	if err := binary.Write(nm.stdin, binary.LittleEndian, length); err != nil {
@@ -46,6 +129,100 @@ func (nm *NativeMessagingManager) SendMessage(ctx context.Context, message map[s
 	return nil
 }
 
+// SendChunked sends message as a single frame when it fits under the
+// outbound limit, or splits its JSON encoding into a series of
+// {"type":"chunk", streamId, seq, total, payload} frames otherwise. A
+// NativeMessagingManager receiving such frames reassembles them in
+// startMessageReader and dispatches the rebuilt message; the mcp-host's own
+// Native Messaging reader has no chunk handling.
+func (nm *NativeMessagingManager) SendChunked(ctx context.Context, message map[string]interface{}) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	limit := nm.maxOutboundFrameSize()
+	if len(jsonData) <= limit {
+		return nm.writeFrame(jsonData)
+	}
+
+	// Each piece is re-encoded as a JSON string inside the envelope, and that
+	// escaping can double it (every quote and backslash gains a backslash).
+	chunkSize := (limit - chunkOverhead) / 2
+	if chunkSize <= 0 {
+		return fmt.Errorf("outbound frame limit %d is too small for chunked transport", limit)
+	}
+
+	payload := string(jsonData)
+	total := (len(payload) + chunkSize - 1) / chunkSize
+	streamID := atomic.AddInt64(&nm.nextStreamID, 1)
+
+	for seq := 0; seq < total; seq++ {
+		start := seq * chunkSize
+		end := start + chunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+
+		chunkData, err := json.Marshal(map[string]interface{}{
+			"type":     "chunk",
+			"streamId": streamID,
+			"seq":      seq,
+			"total":    total,
+			"payload":  payload[start:end],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal chunk %d/%d: %w", seq, total, err)
+		}
+
+		if err := nm.writeFrame(chunkData); err != nil {
+			return fmt.Errorf("failed to write chunk %d/%d: %w", seq, total, err)
+		}
+	}
+
+	return nil
+}
+
+// SendRpcRequest sends an RPC request to the host and blocks until the
+// matching response comes back through startMessageReader, or ctx is done.
+// It turns the manager into a full bidirectional peer: the host can also
+// SendRpcRequest to us, which is how push-style events would be delivered.
+func (nm *NativeMessagingManager) SendRpcRequest(ctx context.Context, method string, params map[string]interface{}) (interface{}, error) {
+	id := atomic.AddInt64(&nm.nextRequestID, 1)
+
+	replyCh := make(chan rpcReply, 1)
+	nm.pendingMu.Lock()
+	if nm.pending == nil {
+		nm.pending = make(map[int64]chan rpcReply)
+	}
+	nm.pending[id] = replyCh
+	nm.pendingMu.Unlock()
+
+	defer func() {
+		nm.pendingMu.Lock()
+		delete(nm.pending, id)
+		nm.pendingMu.Unlock()
+	}()
+
+	message := map[string]interface{}{
+		"type":   "rpc_request",
+		"id":     id,
+		"method": method,
+		"params": params,
+	}
+
+	if err := nm.SendMessage(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to send RPC request: %w", err)
+	}
+
+	select {
+	case reply := <-replyCh:
+		return reply.result, reply.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 func (nm *NativeMessagingManager) SetActionHandler(handler func(action string, params map[string]interface{}) map[string]interface{}) {
 	nm.actionHandler = handler
 }
@@ -98,6 +275,13 @@ func (nm *NativeMessagingManager) startMessageReader(ctx context.Context) {
 				return
 			}
 
+			// Reject oversize frames before allocating a buffer for them, so
+			// an adversarial or buggy peer can't OOM the process.
+			if inboundLimit := nm.maxInboundFrameSize(); int(length) > inboundLimit {
+				nm.errors <- &ErrMessageTooLarge{Direction: "inbound", Size: int(length), Limit: inboundLimit}
+				return
+			}
+
 			// Read JSON data
 			jsonData := make([]byte, length)
 			if _, err := io.ReadFull(reader, jsonData); err != nil {
@@ -105,18 +289,47 @@ func (nm *NativeMessagingManager) startMessageReader(ctx context.Context) {
 				return
 			}
 
-			// Parse JSON
-			var message map[string]interface{}
-			if err := json.Unmarshal(jsonData, &message); err != nil {
+			// Parse JSON as a generic value first since a batch arrives as a
+			// top-level JSON array rather than an object.
+			var raw interface{}
+			if err := json.Unmarshal(jsonData, &raw); err != nil {
 				nm.errors <- fmt.Errorf("failed to unmarshal message: %w", err)
 				return
 			}
 
+			if batch, ok := raw.([]interface{}); ok {
+				nm.handleRpcBatch(ctx, batch)
+				continue
+			}
+
+			message, ok := raw.(map[string]interface{})
+			if !ok {
+				nm.errors <- fmt.Errorf("unexpected message shape: %T", raw)
+				continue
+			}
+
+			if msgType, _ := message["type"].(string); msgType == "chunk" {
+				reassembled, complete, err := nm.receiveChunk(message)
+				if err != nil {
+					nm.errors <- fmt.Errorf("failed to reassemble chunked message: %w", err)
+					continue
+				}
+				if !complete {
+					continue
+				}
+				message = reassembled
+			}
+
 			// Handle RPC requests
 			if nm.handleRpcRequest(ctx, message) {
 				continue
 			}
 
+			// Route replies to a pending SendRpcRequest waiter, if any.
+			if nm.deliverPendingReply(message) {
+				continue
+			}
+
 			// Handle action messages
 			if actionType, ok := message["action"].(string); ok && nm.actionHandler != nil {
 				params, _ := message["params"].(map[string]interface{})
@@ -140,7 +353,119 @@ func (nm *NativeMessagingManager) startMessageReader(ctx context.Context) {
 	}()
 }
 
-// handleRpcRequest processes RPC requests and returns true if the message was handled
+// receiveChunk buffers one piece of a chunked message. It returns the
+// reassembled message and complete=true once every seq 0..total-1 has
+// arrived.
+func (nm *NativeMessagingManager) receiveChunk(chunk map[string]interface{}) (map[string]interface{}, bool, error) {
+	streamID, ok := toInt64(chunk["streamId"])
+	if !ok {
+		return nil, false, fmt.Errorf("chunk missing streamId")
+	}
+	totalF, ok := chunk["total"].(float64)
+	if !ok {
+		return nil, false, fmt.Errorf("chunk missing total")
+	}
+	seqF, ok := chunk["seq"].(float64)
+	if !ok {
+		return nil, false, fmt.Errorf("chunk missing seq")
+	}
+	payload, ok := chunk["payload"].(string)
+	if !ok {
+		return nil, false, fmt.Errorf("chunk missing payload")
+	}
+	total, seq := int(totalF), int(seqF)
+
+	nm.chunksMu.Lock()
+	if nm.chunks == nil {
+		nm.chunks = make(map[int64]*chunkAssembly)
+	}
+	assembly, exists := nm.chunks[streamID]
+	if !exists {
+		assembly = &chunkAssembly{total: total, payloads: make(map[int]string)}
+		nm.chunks[streamID] = assembly
+	}
+	assembly.payloads[seq] = payload
+	complete := len(assembly.payloads) >= assembly.total
+	if complete {
+		delete(nm.chunks, streamID)
+	}
+	nm.chunksMu.Unlock()
+
+	if !complete {
+		return nil, false, nil
+	}
+
+	seqs := make([]int, 0, len(assembly.payloads))
+	for s := range assembly.payloads {
+		seqs = append(seqs, s)
+	}
+	sort.Ints(seqs)
+
+	var full []byte
+	for _, s := range seqs {
+		full = append(full, assembly.payloads[s]...)
+	}
+
+	var message map[string]interface{}
+	if err := json.Unmarshal(full, &message); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal reassembled message: %w", err)
+	}
+
+	return message, true, nil
+}
+
+// handleRpcBatch dispatches a JSON-RPC 2.0 batch: every element is handled
+// concurrently, and the replies are sent back as a single JSON array in the
+// same order, with notifications (entries with no "id") omitted entirely.
+func (nm *NativeMessagingManager) handleRpcBatch(ctx context.Context, batch []interface{}) {
+	responses := make([]map[string]interface{}, len(batch))
+
+	var wg sync.WaitGroup
+	for i, entry := range batch {
+		message, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, message map[string]interface{}) {
+			defer wg.Done()
+			responses[i] = nm.processRpcEntry(message)
+		}(i, message)
+	}
+	wg.Wait()
+
+	batchResponse := make([]map[string]interface{}, 0, len(responses))
+	for _, response := range responses {
+		if response != nil {
+			batchResponse = append(batchResponse, response)
+		}
+	}
+
+	if len(batchResponse) == 0 {
+		return
+	}
+
+	go func() {
+		if err := nm.sendRpcBatchResponse(ctx, batchResponse); err != nil {
+			nm.errors <- fmt.Errorf("failed to send RPC batch response: %w", err)
+		}
+	}()
+}
+
+// sendRpcBatchResponse writes a raw JSON array frame, bypassing SendMessage
+// (which only knows how to marshal a single object).
+func (nm *NativeMessagingManager) sendRpcBatchResponse(ctx context.Context, responses []map[string]interface{}) error {
+	jsonData, err := json.Marshal(responses)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch response: %w", err)
+	}
+
+	return nm.writeFrame(jsonData)
+}
+
+// handleRpcRequest processes a single (non-batch) RPC request and returns
+// true if the message was recognized and handled.
 func (nm *NativeMessagingManager) handleRpcRequest(ctx context.Context, message map[string]interface{}) bool {
 	// Check if this is an RPC request (type: "rpc_request")
 	msgType, hasType := message["type"].(string)
@@ -148,42 +473,56 @@ func (nm *NativeMessagingManager) handleRpcRequest(ctx context.Context, message
 		return false // Not an RPC request
 	}
 
-	// Extract method and id from the RPC request
-	method, hasMethod := message["method"].(string)
-	id, hasId := message["id"]
-
-	if !hasMethod || !hasId {
+	_, hasMethod := message["method"].(string)
+	if !hasMethod {
 		return false // Invalid RPC request
 	}
 
-	// Look up the handler
-	handler, exists := nm.rpcHandlers[method]
-	if !exists {
-		// Send error response for unknown method
-		nm.sendRpcResponse(ctx, id, nil, fmt.Errorf("unknown RPC method: %s", method))
+	response := nm.processRpcEntry(message)
+
+	// A request with no "id" is a notification: the handler still runs (via
+	// processRpcEntry) but no response is sent back.
+	if response == nil {
 		return true
 	}
 
-	// Extract parameters
+	go func() {
+		if sendErr := nm.SendMessage(ctx, response); sendErr != nil {
+			nm.errors <- fmt.Errorf("failed to send RPC response: %w", sendErr)
+		}
+	}()
+	return true
+}
+
+// processRpcEntry executes the registered handler for message's method and
+// builds the JSON-RPC response object. It returns nil when message carries
+// no "id" (a notification), since notifications never produce a response.
+func (nm *NativeMessagingManager) processRpcEntry(message map[string]interface{}) map[string]interface{} {
+	method, _ := message["method"].(string)
+	id, hasID := message["id"]
+
 	params, _ := message["params"].(map[string]interface{})
 	if params == nil {
 		params = make(map[string]interface{})
 	}
 
-	// Execute the handler
-	result, err := handler(params)
+	handler, exists := nm.rpcHandlers[method]
+	var result interface{}
+	var err error
+	if !exists {
+		err = fmt.Errorf("unknown RPC method: %s", method)
+	} else {
+		result, err = handler(params)
+	}
 
-	// Send response
-	nm.sendRpcResponse(ctx, id, result, err)
-	return true
-}
+	if !hasID {
+		// Notification: handler ran for its side effects, no reply expected.
+		return nil
+	}
 
-// sendRpcResponse sends an RPC response back to the MCP host
-func (nm *NativeMessagingManager) sendRpcResponse(ctx context.Context, id interface{}, result interface{}, err error) {
 	response := map[string]interface{}{
 		"id": id,
 	}
-
 	if err != nil {
 		response["error"] = map[string]interface{}{
 			"message": err.Error(),
@@ -191,13 +530,64 @@ func (nm *NativeMessagingManager) sendRpcResponse(ctx context.Context, id interf
 	} else {
 		response["result"] = result
 	}
+	return response
+}
 
-	// Send response asynchronously to avoid blocking
-	go func() {
-		if sendErr := nm.SendMessage(ctx, response); sendErr != nil {
-			nm.errors <- fmt.Errorf("failed to send RPC response: %w", sendErr)
+// deliverPendingReply routes a reply to the goroutine blocked in
+// SendRpcRequest for the matching id, if one is waiting. Returns true if the
+// message was consumed this way.
+func (nm *NativeMessagingManager) deliverPendingReply(message map[string]interface{}) bool {
+	idVal, hasID := message["id"]
+	if !hasID {
+		return false
+	}
+
+	id, ok := toInt64(idVal)
+	if !ok {
+		return false
+	}
+
+	nm.pendingMu.Lock()
+	replyCh, exists := nm.pending[id]
+	if exists {
+		delete(nm.pending, id)
+	}
+	nm.pendingMu.Unlock()
+
+	if !exists {
+		return false
+	}
+
+	reply := rpcReply{result: message["result"]}
+	if errVal, hasErr := message["error"]; hasErr {
+		if errMap, ok := errVal.(map[string]interface{}); ok {
+			if msg, ok := errMap["message"].(string); ok {
+				reply.err = fmt.Errorf("%s", msg)
+			} else {
+				reply.err = fmt.Errorf("rpc error: %v", errVal)
+			}
+		} else {
+			reply.err = fmt.Errorf("rpc error: %v", errVal)
 		}
-	}()
+	}
+
+	replyCh <- reply
+	return true
+}
+
+// toInt64 converts the decoded JSON representation of an id (typically
+// float64, but accepted as int64/int too) to an int64 for map lookups.
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int64(n), true
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	default:
+		return 0, false
+	}
 }
 
 func (nm *NativeMessagingManager) Close() error {
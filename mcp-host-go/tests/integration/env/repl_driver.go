@@ -0,0 +1,97 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/algonius/algonius-browser/mcp-host-go/pkg/repl"
+)
+
+// nativeMsgRouter adapts NativeMessagingManager's RPC channel to the
+// repl.Router interface, so integration tests can drive a REPL session
+// against the same execute_tool/list_tools/get_resource RPC methods the
+// real mcp-host binary's repl subcommand would call in-process.
+type nativeMsgRouter struct {
+	nativeMsg *NativeMessagingManager
+	timeout   time.Duration
+}
+
+func (r *nativeMsgRouter) call(method string, params map[string]interface{}) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	return r.nativeMsg.SendRpcRequest(ctx, method, params)
+}
+
+func (r *nativeMsgRouter) ListTools() ([]repl.ToolInfo, error) {
+	result, err := r.call("list_tools", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rawList, ok := result.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("list_tools: unexpected result shape: %T", result)
+	}
+
+	tools := make([]repl.ToolInfo, 0, len(rawList))
+	for _, raw := range rawList {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		tools = append(tools, repl.ToolInfo{
+			Name:        fmt.Sprintf("%v", entry["name"]),
+			Description: fmt.Sprintf("%v", entry["description"]),
+			InputSchema: entry["inputSchema"],
+		})
+	}
+	return tools, nil
+}
+
+func (r *nativeMsgRouter) ExecuteTool(name string, args map[string]interface{}) (repl.ToolResult, error) {
+	result, err := r.call("execute_tool", map[string]interface{}{
+		"name": name,
+		"args": args,
+	})
+	if err != nil {
+		return repl.ToolResult{}, err
+	}
+
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return repl.ToolResult{}, fmt.Errorf("execute_tool: unexpected result shape: %T", result)
+	}
+
+	rawContent, _ := resultMap["Content"].([]interface{})
+	items := make([]repl.ToolResultItem, 0, len(rawContent))
+	for _, raw := range rawContent {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		items = append(items, repl.ToolResultItem{
+			Type: fmt.Sprintf("%v", entry["Type"]),
+			Text: fmt.Sprintf("%v", entry["Text"]),
+		})
+	}
+	return repl.ToolResult{Content: items}, nil
+}
+
+func (r *nativeMsgRouter) ReadResource(uri string) (interface{}, error) {
+	return r.call("get_resource", map[string]interface{}{"uri": uri})
+}
+
+// NewReplDriver returns a repl.Repl wired to this environment's running
+// mcp-host process, so integration tests can type the same commands an
+// operator would at `mcp-host repl` and assert on the printed ToolResult
+// content, instead of hand-building RPC requests.
+func (env *McpHostTestEnvironment) NewReplDriver(out io.Writer) (*repl.Repl, error) {
+	if env.nativeMsg == nil {
+		return nil, fmt.Errorf("native messaging manager not initialized; call Setup first")
+	}
+
+	router := &nativeMsgRouter{nativeMsg: env.nativeMsg, timeout: 15 * time.Second}
+	return repl.New(repl.Config{Router: router, Out: out})
+}
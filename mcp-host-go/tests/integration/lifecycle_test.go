@@ -77,7 +77,7 @@ func TestSSEServerConnectivity(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test basic connectivity by trying to list resources
-	resources, err := testEnv.GetMcpClient().ListResources()
+	resources, err := testEnv.GetMcpClient().ListResources(ctx)
 	if err != nil {
 		// If the endpoint doesn't exist yet, that's okay for this basic test
 		t.Logf("ListResources failed (expected if not implemented): %v", err)
@@ -127,7 +127,7 @@ func TestBrowserStateResource(t *testing.T) {
 	})
 
 	// Try to verify resource is available through MCP client
-	resources, err := testEnv.GetMcpClient().ListResources()
+	resources, err := testEnv.GetMcpClient().ListResources(ctx)
 	if err != nil {
 		t.Logf("ListResources failed (expected if not implemented): %v", err)
 		return
@@ -145,7 +145,7 @@ func TestBrowserStateResource(t *testing.T) {
 		t.Log("Successfully found browser://current/state resource")
 
 		// Try to read the resource content
-		resourceContent, err := testEnv.GetMcpClient().ReadResource("browser://current/state")
+		resourceContent, err := testEnv.GetMcpClient().ReadResource(ctx, "browser://current/state")
 		if err != nil {
 			t.Logf("ReadResource failed: %v", err)
 		} else {
@@ -195,7 +195,7 @@ func TestNavigateToTool(t *testing.T) {
 	require.NoError(t, err)
 
 	// Try to verify tool is available
-	tools, err := testEnv.GetMcpClient().ListTools()
+	tools, err := testEnv.GetMcpClient().ListTools(ctx)
 	if err != nil {
 		t.Logf("ListTools failed (expected if not implemented): %v", err)
 		return
@@ -217,7 +217,7 @@ func TestNavigateToTool(t *testing.T) {
 	t.Log("Successfully found navigate_to tool")
 
 	// Execute navigation tool via MCP client
-	result, err := testEnv.GetMcpClient().CallTool("navigate_to", map[string]interface{}{
+	result, err := testEnv.GetMcpClient().CallTool(ctx, "navigate_to", map[string]interface{}{
 		"url": "https://test.com",
 	})
 
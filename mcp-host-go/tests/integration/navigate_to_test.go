@@ -40,7 +40,7 @@ func TestNavigateToToolTimeout(t *testing.T) {
 
 	t.Run("navigate with default timeout", func(t *testing.T) {
 		// Test navigation with default (auto) timeout
-		result, err := testEnv.GetMcpClient().CallTool("navigate_to", map[string]interface{}{
+		result, err := testEnv.GetMcpClient().CallTool(ctx, "navigate_to", map[string]interface{}{
 			"url": "https://httpbin.org/delay/1",
 		})
 
@@ -60,7 +60,7 @@ func TestNavigateToToolTimeout(t *testing.T) {
 		capturedNavigation = nil // Reset
 
 		// Test navigation with custom timeout
-		result, err := testEnv.GetMcpClient().CallTool("navigate_to", map[string]interface{}{
+		result, err := testEnv.GetMcpClient().CallTool(ctx, "navigate_to", map[string]interface{}{
 			"url":     "https://httpbin.org/delay/1",
 			"timeout": "10000",
 		})
@@ -79,7 +79,7 @@ func TestNavigateToToolTimeout(t *testing.T) {
 
 	t.Run("navigate with invalid timeout", func(t *testing.T) {
 		// Test navigation with invalid timeout (too short)
-		result, err := testEnv.GetMcpClient().CallTool("navigate_to", map[string]interface{}{
+		result, err := testEnv.GetMcpClient().CallTool(ctx, "navigate_to", map[string]interface{}{
 			"url":     "https://httpbin.org/delay/1",
 			"timeout": "500", // Too short
 		})
@@ -96,7 +96,7 @@ func TestNavigateToToolTimeout(t *testing.T) {
 		capturedNavigation = nil // Reset
 
 		// Test navigation with explicit auto timeout
-		result, err := testEnv.GetMcpClient().CallTool("navigate_to", map[string]interface{}{
+		result, err := testEnv.GetMcpClient().CallTool(ctx, "navigate_to", map[string]interface{}{
 			"url":     "https://httpbin.org/delay/1",
 			"timeout": "auto",
 		})
@@ -130,7 +130,7 @@ func TestNavigateToToolSchema(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("verify schema includes timeout parameter", func(t *testing.T) {
-		tools, err := testEnv.GetMcpClient().ListTools()
+		tools, err := testEnv.GetMcpClient().ListTools(ctx)
 		require.NoError(t, err, "Should be able to list tools")
 
 		var navigateToTool *mcp.Tool
@@ -59,7 +59,7 @@ func TestSetValueToolBasicFunctionality(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify set_value tool is available
-	tools, err := testEnv.GetMcpClient().ListTools()
+	tools, err := testEnv.GetMcpClient().ListTools(ctx)
 	if err != nil {
 		t.Logf("ListTools failed (expected if not implemented): %v", err)
 		return
@@ -86,7 +86,7 @@ func TestSetValueToolBasicFunctionality(t *testing.T) {
 		capturedSetValueRequests = nil
 
 		// Execute set_value tool
-		result, err := testEnv.GetMcpClient().CallTool("set_value", map[string]interface{}{
+		result, err := testEnv.GetMcpClient().CallTool(ctx, "set_value", map[string]interface{}{
 			"target":      0,
 			"target_type": "index",
 			"value":       "Hello World",
@@ -141,7 +141,7 @@ func TestSetValueToolParameterValidation(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify set_value tool is available
-	tools, err := testEnv.GetMcpClient().ListTools()
+	tools, err := testEnv.GetMcpClient().ListTools(ctx)
 	if err != nil {
 		t.Logf("ListTools failed: %v", err)
 		return
@@ -216,7 +216,7 @@ func TestSetValueToolParameterValidation(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result, err := testEnv.GetMcpClient().CallTool("set_value", tc.args)
+			result, err := testEnv.GetMcpClient().CallTool(ctx, "set_value", tc.args)
 
 			if tc.expectError {
 				// For parameter validation errors, we expect either an error or IsError=true
@@ -295,7 +295,7 @@ func TestSetValueToolDifferentElementTypes(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify set_value tool is available
-	tools, err := testEnv.GetMcpClient().ListTools()
+	tools, err := testEnv.GetMcpClient().ListTools(ctx)
 	if err != nil {
 		t.Logf("ListTools failed: %v", err)
 		return
@@ -350,7 +350,7 @@ func TestSetValueToolDifferentElementTypes(t *testing.T) {
 			// Clear previous requests
 			capturedRequests = nil
 
-			result, err := testEnv.GetMcpClient().CallTool("set_value", map[string]interface{}{
+			result, err := testEnv.GetMcpClient().CallTool(ctx, "set_value", map[string]interface{}{
 				"target":      tc.target,
 				"target_type": "index",
 				"value":       tc.value,
@@ -413,7 +413,7 @@ func TestSetValueToolWithDescription(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify set_value tool is available
-	tools, err := testEnv.GetMcpClient().ListTools()
+	tools, err := testEnv.GetMcpClient().ListTools(ctx)
 	if err != nil {
 		t.Logf("ListTools failed: %v", err)
 		return
@@ -437,7 +437,7 @@ func TestSetValueToolWithDescription(t *testing.T) {
 		// Clear previous requests
 		capturedRequests = nil
 
-		result, err := testEnv.GetMcpClient().CallTool("set_value", map[string]interface{}{
+		result, err := testEnv.GetMcpClient().CallTool(ctx, "set_value", map[string]interface{}{
 			"target":      "Enter your name",
 			"target_type": "description",
 			"value":       "John Doe",
@@ -476,7 +476,7 @@ func TestSetValueToolSchema(t *testing.T) {
 	require.NoError(t, err)
 
 	// Get tools list
-	tools, err := testEnv.GetMcpClient().ListTools()
+	tools, err := testEnv.GetMcpClient().ListTools(ctx)
 	if err != nil {
 		t.Logf("ListTools failed: %v", err)
 		return